@@ -0,0 +1,137 @@
+// Package avss implements the bivariate sharing at the core of the
+// asynchronous verifiable secret sharing of Cachin, Kursawe, Lysyanskaya and
+// Strobl. The dealer shares the secret with a bivariate polynomial F of
+// degree <= t in each variable and sends party i its row F(x_i, y) and its
+// column F(x, x_i). Parties echo their common points to each other; a party
+// that missed its row, or received a wrong one, recovers it from the echoes
+// by decoding the Reed-Solomon code of package reedsolomonP, which tolerates
+// wrong echoes from corrupted parties.
+package avss
+
+import (
+	"errors"
+	"fmt"
+
+	"oec/field"
+	"oec/reedsolomonP"
+	"oec/utils"
+)
+
+// Sharing fixes the field, the threshold t and the number of parties n.
+// Party i evaluates at the field element encoded by i.
+type Sharing[E any] struct {
+	t    int
+	n    int
+	f    field.Field[E]
+	xs   []E
+	code *reedsolomonP.RSGF[E]
+}
+
+// Point is a value party From sends to another party about that party's
+// row: F(x_to, x_from), the value of the sender's column at x_to.
+type Point[E any] struct {
+	From  int
+	Value E
+}
+
+// NewSharing returns the bivariate sharing with threshold t among n parties
+// over f. It requires n >= 3t+1.
+func NewSharing[E any](t, n int, f field.Field[E]) (*Sharing[E], error) {
+	if t < 0 || 3*t+1 > n {
+		return nil, errors.New("requires 0 <= t and 3t+1 <= n")
+	}
+	code, err := reedsolomonP.NewRSGF[E](t+1, n, f, reedsolomonP.WithDomain(reedsolomonP.ConsecutiveDomain[E](1)))
+	if err != nil {
+		return nil, err
+	}
+	xs := code.Points()
+	for i, x := range xs {
+		if f.IsZero(x) {
+			return nil, fmt.Errorf("party %d evaluates at zero", i+1)
+		}
+	}
+	return &Sharing[E]{t: t, n: n, f: f, xs: xs, code: code}, nil
+}
+
+// Threshold returns t.
+func (s *Sharing[E]) Threshold() int {
+	return s.t
+}
+
+// Parties returns n.
+func (s *Sharing[E]) Parties() int {
+	return s.n
+}
+
+// Point returns the evaluation point of party index.
+func (s *Sharing[E]) Point(index int) E {
+	return s.xs[index-1]
+}
+
+// Deal returns a random symmetric bivariate polynomial of degree <= t with
+// F(0, 0) = secret. Its rows are its columns.
+func (s *Sharing[E]) Deal(secret E) (utils.BiPolyOf[E], error) {
+	return utils.NewRandSymmetricBiPolyOf(s.f, s.t, secret)
+}
+
+// DealAsymmetric returns a random bivariate polynomial of degree <= t in
+// each variable with F(0, 0) = secret.
+func (s *Sharing[E]) DealAsymmetric(secret E) (utils.BiPolyOf[E], error) {
+	return utils.NewRandBiPolyOf(s.f, s.t, s.t, secret)
+}
+
+// Row returns the row F(x_i, y) the dealer sends to party index.
+func (s *Sharing[E]) Row(b utils.BiPolyOf[E], index int) utils.PolyOf[E] {
+	return b.Row(s.Point(index))
+}
+
+// Column returns the column F(x, x_i) the dealer sends to party index.
+func (s *Sharing[E]) Column(b utils.BiPolyOf[E], index int) utils.PolyOf[E] {
+	return b.Column(s.Point(index))
+}
+
+// Echo returns the point party from sends to party to about the row of to,
+// given the column of from. For a symmetric polynomial that is its row.
+func (s *Sharing[E]) Echo(column utils.PolyOf[E], from, to int) Point[E] {
+	return Point[E]{From: from, Value: column.Eval(s.Point(to))}
+}
+
+// Verify reports whether the point received from another party lies on the
+// row of party index.
+func (s *Sharing[E]) Verify(row utils.PolyOf[E], p Point[E]) bool {
+	if p.From < 1 || p.From > s.n || row.Degree() > s.t {
+		return false
+	}
+	return s.f.Equal(row.Eval(s.Point(p.From)), p.Value)
+}
+
+// RecoverRow decodes a row from the points echoed by other parties. With m
+// points from distinct parties, up to (m-t-1)/2 of them may be wrong; the
+// parties that sent those are returned as well.
+func (s *Sharing[E]) RecoverRow(points []Point[E]) (utils.PolyOf[E], []int, error) {
+	if len(points) < s.t+1 {
+		return utils.PolyOf[E]{}, nil, errors.New("too few points given")
+	}
+	received := make([]reedsolomonP.ShareOf[E], len(points))
+	for i, p := range points {
+		if p.From < 1 || p.From > s.n {
+			return utils.PolyOf[E]{}, nil, fmt.Errorf("invalid party %d", p.From)
+		}
+		received[i] = reedsolomonP.ShareOf[E]{Number: p.From - 1, Data: p.Value}
+	}
+	result, err := s.code.Decode(received)
+	if err != nil {
+		return utils.PolyOf[E]{}, nil, err
+	}
+	faulty := make([]int, len(result.Faulty))
+	for i, number := range result.Faulty {
+		faulty[i] = number + 1
+	}
+	return utils.NewPolyOf(s.f, result.Message...), faulty, nil
+}
+
+// Share returns the share of the secret held by the party with the given
+// row: F(x_i, 0), its share of the univariate sharing F(x, 0).
+func (s *Sharing[E]) Share(row utils.PolyOf[E]) E {
+	return row.Eval(s.f.Zero())
+}
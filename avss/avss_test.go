@@ -0,0 +1,109 @@
+package avss
+
+import (
+	"math/big"
+	"testing"
+
+	"oec/field"
+	"oec/utils"
+)
+
+func TestRecoverRow(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	s, err := NewSharing[uint64](2, 7, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := f.FromInt64(42)
+	b, err := s.Deal(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsSymmetric() {
+		t.Fatal("dealt polynomial is not symmetric")
+	}
+
+	// party 1 missed its row and recovers it from the echoes of the others,
+	// of which party 4 lies
+	want := s.Row(b, 1)
+	var points []Point[uint64]
+	for j := 2; j <= s.Parties(); j++ {
+		p := s.Echo(s.Row(b, j), j, 1)
+		if !s.Verify(want, p) {
+			t.Errorf("echo of party %d does not lie on the row", j)
+		}
+		if j == 4 {
+			p.Value = f.Add(p.Value, f.One())
+			if s.Verify(want, p) {
+				t.Errorf("wrong echo of party %d verified", j)
+			}
+		}
+		points = append(points, p)
+	}
+	row, faulty, err := s.RecoverRow(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !row.Equal(want) {
+		t.Errorf("recovered row %v, want %v", row, want)
+	}
+	if len(faulty) != 1 || faulty[0] != 4 {
+		t.Errorf("expected faulty party [4], got %v", faulty)
+	}
+
+	// the shares F(x_i, 0) reconstruct the secret
+	xs := make([]uint64, 0, s.Threshold()+1)
+	ys := make([]uint64, 0, s.Threshold()+1)
+	for i := 1; i <= s.Threshold()+1; i++ {
+		xs = append(xs, s.Point(i))
+		ys = append(ys, s.Share(s.Row(b, i)))
+	}
+	lambda, err := utils.LagrangeCoefficients[uint64](f, xs, f.Zero())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := f.Zero()
+	for i, c := range lambda {
+		got = f.Add(got, f.Mul(c, ys[i]))
+	}
+	if got != secret {
+		t.Errorf("reconstructed %d, want %d", got, secret)
+	}
+
+	if _, _, err := s.RecoverRow(points[:s.Threshold()]); err == nil {
+		t.Errorf("recovered a row from t points")
+	}
+}
+
+func TestRecoverRow_Asymmetric(t *testing.T) {
+	f := field.NewPrime(big.NewInt(1000003))
+	s, _ := NewSharing[*big.Int](1, 4, f)
+	b, err := s.DealAsymmetric(f.FromInt64(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// party 2 recovers its row F(x_2, y) from the columns of the others
+	want := s.Row(b, 2)
+	var points []Point[*big.Int]
+	for _, j := range []int{1, 3, 4} {
+		column := s.Column(b, j)
+		if !utils.ConsistentPoint(want, s.Point(2), column, s.Point(j)) {
+			t.Errorf("row of party 2 and column of party %d disagree", j)
+		}
+		points = append(points, s.Echo(column, j, 2))
+	}
+	row, faulty, err := s.RecoverRow(points)
+	if err != nil || !row.Equal(want) || len(faulty) != 0 {
+		t.Errorf("recovered %v, faulty %v: %v", row, faulty, err)
+	}
+}
+
+func TestNewSharing(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	if _, err := NewSharing[uint64](2, 6, f); err == nil {
+		t.Errorf("accepted n < 3t+1")
+	}
+	if _, err := NewSharing[uint64](-1, 4, f); err == nil {
+		t.Errorf("accepted negative threshold")
+	}
+}
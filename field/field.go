@@ -0,0 +1,107 @@
+// Package field defines the finite field abstraction the codes and
+// polynomials in this module are generic over.
+package field
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// ErrNoInverse is returned when inverting the zero element.
+var ErrNoInverse = errors.New("zero has no inverse")
+
+// Field is a finite field with elements of type E.
+//
+// Elements returned by a Field are always in canonical form. Every element
+// also has an integer encoding in [0, q): FromInt64 and FromBig map an
+// encoding to its element and ToBig maps back. For prime fields the encoding
+// is the residue itself; in other fields it is not the integer embedding, so
+// use MulInt to multiply by an integer.
+type Field[E any] interface {
+	// Order returns the number of elements q.
+	Order() *big.Int
+	// Characteristic returns the characteristic of the field.
+	Characteristic() *big.Int
+
+	Zero() E
+	One() E
+	// FromInt64 returns the element encoded by v, reduced into [0, q).
+	FromInt64(v int64) E
+	// FromBig returns the element encoded by v, reduced into [0, q).
+	FromBig(v *big.Int) E
+	// ToBig returns the encoding of a.
+	ToBig(a E) *big.Int
+
+	Add(a, b E) E
+	Sub(a, b E) E
+	Neg(a E) E
+	Mul(a, b E) E
+	// Inv returns 1/a, or ErrNoInverse for a = 0.
+	Inv(a E) (E, error)
+
+	Equal(a, b E) bool
+	IsZero(a E) bool
+
+	// Rand returns a uniformly random element read from r.
+	Rand(r io.Reader) (E, error)
+}
+
+// Div returns a/b.
+func Div[E any](f Field[E], a, b E) (E, error) {
+	inv, err := f.Inv(b)
+	if err != nil {
+		return f.Zero(), err
+	}
+	return f.Mul(a, inv), nil
+}
+
+// Exp returns a^e for e >= 0.
+func Exp[E any](f Field[E], a E, e *big.Int) E {
+	result := f.One()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		result = f.Mul(result, result)
+		if e.Bit(i) == 1 {
+			result = f.Mul(result, a)
+		}
+	}
+	return result
+}
+
+// ExpInt returns a^e for e >= 0.
+func ExpInt[E any](f Field[E], a E, e int) E {
+	return Exp(f, a, big.NewInt(int64(e)))
+}
+
+// MulInt returns n*a = a + a + ... + a (n times), which in characteristic c
+// only depends on n mod c.
+func MulInt[E any](f Field[E], a E, n int) E {
+	if n < 0 {
+		return f.Neg(MulInt(f, a, -n))
+	}
+	result := f.Zero()
+	for n > 0 {
+		if n&1 == 1 {
+			result = f.Add(result, a)
+		}
+		a = f.Add(a, a)
+		n >>= 1
+	}
+	return result
+}
+
+// Same reports whether a and b are the same field, so that the elements of
+// one are the elements of the other. Fields of the same order are
+// isomorphic, but GF(2^m) built from different reduction polynomials encodes
+// its elements differently, so for GF2m the polynomials must match as well.
+func Same[E any](a, b Field[E]) bool {
+	if a.Order().Cmp(b.Order()) != 0 {
+		return false
+	}
+	ga, ok := any(a).(*GF2m)
+	if !ok {
+		return true
+	}
+	gb, ok := any(b).(*GF2m)
+	return !ok || ga.Polynomial() == gb.Polynomial()
+}
@@ -0,0 +1,167 @@
+package field
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// defaultPrimitive lists a primitive polynomial of degree m for m = 1..16,
+// with bit i holding the coefficient of x^i.
+var defaultPrimitive = [...]uint32{
+	1:  0x3,
+	2:  0x7,
+	3:  0xb,
+	4:  0x13,
+	5:  0x25,
+	6:  0x43,
+	7:  0x89,
+	8:  0x11d,
+	9:  0x211,
+	10: 0x409,
+	11: 0x805,
+	12: 0x1053,
+	13: 0x201b,
+	14: 0x4443,
+	15: 0x8003,
+	16: 0x1100b,
+}
+
+// GF2m is the binary extension field GF(2^m) for 1 <= m <= 16, built as
+// GF(2)[x] modulo a primitive polynomial. An element is the uint16 whose bit
+// i is the coefficient of x^i, so addition is XOR. Multiplication and
+// inversion use log and antilog tables of the primitive element x.
+type GF2m struct {
+	m    int
+	poly uint32
+	exp  []uint16 // exp[i] = x^i, doubled so that exp[log a + log b] needs no reduction
+	log  []int    // log[a] for a != 0
+}
+
+// NewGF2m returns the field GF(2^m) defined by the primitive polynomial poly,
+// with bit i holding the coefficient of x^i. A poly of 0 selects a default
+// primitive polynomial, 0x11d for m = 8.
+func NewGF2m(m int, poly uint32) (*GF2m, error) {
+	if m < 1 || m > 16 {
+		return nil, errors.New("requires 1 <= m <= 16")
+	}
+	if poly == 0 {
+		poly = defaultPrimitive[m]
+	}
+	if poly>>uint(m) != 1 {
+		return nil, fmt.Errorf("polynomial %#x does not have degree %d", poly, m)
+	}
+
+	size := 1<<uint(m) - 1
+	f := &GF2m{
+		m:    m,
+		poly: poly,
+		exp:  make([]uint16, 2*size),
+		log:  make([]int, size+1),
+	}
+	for i := range f.log {
+		f.log[i] = -1
+	}
+	a := uint32(1)
+	for i := 0; i < size; i++ {
+		if f.log[a] >= 0 {
+			// x has order i < 2^m - 1
+			return nil, fmt.Errorf("polynomial %#x is not primitive", poly)
+		}
+		f.exp[i] = uint16(a)
+		f.exp[i+size] = uint16(a)
+		f.log[a] = i
+		a <<= 1
+		if a>>uint(m) == 1 {
+			a ^= poly
+		}
+	}
+	return f, nil
+}
+
+// Degree returns m.
+func (f *GF2m) Degree() int {
+	return f.m
+}
+
+// Polynomial returns the primitive polynomial defining the field.
+func (f *GF2m) Polynomial() uint32 {
+	return f.poly
+}
+
+func (f *GF2m) Order() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(f.m))
+}
+
+func (f *GF2m) Characteristic() *big.Int {
+	return big.NewInt(2)
+}
+
+func (f *GF2m) Zero() uint16 {
+	return 0
+}
+
+func (f *GF2m) One() uint16 {
+	return 1
+}
+
+func (f *GF2m) FromInt64(v int64) uint16 {
+	return uint16(v & (1<<uint(f.m) - 1))
+}
+
+func (f *GF2m) FromBig(v *big.Int) uint16 {
+	mask := big.NewInt(1<<uint(f.m) - 1)
+	return uint16(new(big.Int).And(v, mask).Uint64())
+}
+
+func (f *GF2m) ToBig(a uint16) *big.Int {
+	return big.NewInt(int64(a))
+}
+
+func (f *GF2m) Add(a, b uint16) uint16 {
+	return a ^ b
+}
+
+func (f *GF2m) Sub(a, b uint16) uint16 {
+	return a ^ b
+}
+
+func (f *GF2m) Neg(a uint16) uint16 {
+	return a
+}
+
+func (f *GF2m) Mul(a, b uint16) uint16 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[f.log[a]+f.log[b]]
+}
+
+func (f *GF2m) Inv(a uint16) (uint16, error) {
+	if a == 0 {
+		return 0, ErrNoInverse
+	}
+	size := len(f.log) - 1
+	return f.exp[(size-f.log[a])%size], nil
+}
+
+func (f *GF2m) Equal(a, b uint16) bool {
+	return a == b
+}
+
+func (f *GF2m) IsZero(a uint16) bool {
+	return a == 0
+}
+
+func (f *GF2m) Rand(r io.Reader) (uint16, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	var buf [2]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	return f.FromInt64(int64(buf[0]) | int64(buf[1])<<8), nil
+}
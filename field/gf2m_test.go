@@ -0,0 +1,75 @@
+package field
+
+import (
+	"testing"
+)
+
+// clmul multiplies a and b as polynomials over GF(2) modulo poly of degree m.
+func clmul(a, b uint16, poly uint32, m int) uint16 {
+	var r uint32
+	x := uint32(a)
+	for i := 0; i < m; i++ {
+		if b>>uint(i)&1 == 1 {
+			r ^= x
+		}
+		x <<= 1
+		if x>>uint(m) == 1 {
+			x ^= poly
+		}
+	}
+	return uint16(r)
+}
+
+func TestGF2m(t *testing.T) {
+	for m := 1; m <= 16; m++ {
+		f, err := NewGF2m(m, 0)
+		if err != nil {
+			t.Fatalf("m=%d: %v", m, err)
+		}
+		if f.Order().Int64() != 1<<uint(m) {
+			t.Errorf("m=%d: unexpected order %v", m, f.Order())
+		}
+		step := max(1, (1<<uint(m))/61)
+		for a := 1; a < 1<<uint(m); a += step {
+			x := uint16(a)
+			inv, err := f.Inv(x)
+			if err != nil || f.Mul(x, inv) != 1 {
+				t.Fatalf("m=%d: 1/%d", m, a)
+			}
+			for b := 0; b < 1<<uint(m); b += step {
+				y := uint16(b)
+				if got, want := f.Mul(x, y), clmul(x, y, f.Polynomial(), m); got != want {
+					t.Fatalf("m=%d: %d * %d = %d, expected %d", m, a, b, got, want)
+				}
+			}
+		}
+	}
+
+	f, _ := NewGF2m(8, 0)
+	if f.Add(0x53, 0xca) != 0x99 || f.Mul(0x53, 0xca) != clmul(0x53, 0xca, 0x11d, 8) {
+		t.Errorf("unexpected GF(2^8) arithmetic")
+	}
+	if MulInt[uint16](f, 0x53, 3) != 0x53 || MulInt[uint16](f, 0x53, 4) != 0 {
+		t.Errorf("integer multiples do not depend on the parity")
+	}
+	if _, err := f.Inv(0); err != ErrNoInverse {
+		t.Errorf("expected ErrNoInverse, got %v", err)
+	}
+
+	// 0x11b is irreducible, but x only has order 51 modulo it
+	a, _ := NewGF2m(4, 0)
+	b, _ := NewGF2m(4, 0x13)
+	c, _ := NewGF2m(4, 0x19)
+	if !Same[uint16](a, b) || Same[uint16](a, c) {
+		t.Errorf("Same does not compare the reduction polynomial")
+	}
+
+	for _, tc := range []struct {
+		m    int
+		poly uint32
+	}{{8, 0x11b}, {8, 0x1d}, {0, 0x3}, {17, 0}} {
+		if _, err := NewGF2m(tc.m, tc.poly); err == nil {
+			t.Errorf("expected error for m=%d poly=%#x", tc.m, tc.poly)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package field
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+)
+
+// Prime is the prime field GF(p). Its elements are *big.Int values in [0, p);
+// inputs outside that range are accepted and treated modulo p.
+type Prime struct {
+	p *big.Int
+}
+
+// NewPrime returns the field GF(p). p must be prime.
+func NewPrime(p *big.Int) *Prime {
+	return &Prime{p: new(big.Int).Set(p)}
+}
+
+// Modulus returns p.
+func (f *Prime) Modulus() *big.Int {
+	return new(big.Int).Set(f.p)
+}
+
+func (f *Prime) Order() *big.Int {
+	return new(big.Int).Set(f.p)
+}
+
+func (f *Prime) Characteristic() *big.Int {
+	return new(big.Int).Set(f.p)
+}
+
+func (f *Prime) Zero() *big.Int {
+	return big.NewInt(0)
+}
+
+func (f *Prime) One() *big.Int {
+	return big.NewInt(1)
+}
+
+func (f *Prime) FromInt64(v int64) *big.Int {
+	return new(big.Int).Mod(big.NewInt(v), f.p)
+}
+
+func (f *Prime) FromBig(v *big.Int) *big.Int {
+	return new(big.Int).Mod(v, f.p)
+}
+
+func (f *Prime) ToBig(a *big.Int) *big.Int {
+	return new(big.Int).Mod(a, f.p)
+}
+
+func (f *Prime) Add(a, b *big.Int) *big.Int {
+	r := new(big.Int).Add(a, b)
+	return r.Mod(r, f.p)
+}
+
+func (f *Prime) Sub(a, b *big.Int) *big.Int {
+	r := new(big.Int).Sub(a, b)
+	return r.Mod(r, f.p)
+}
+
+func (f *Prime) Neg(a *big.Int) *big.Int {
+	r := new(big.Int).Neg(a)
+	return r.Mod(r, f.p)
+}
+
+func (f *Prime) Mul(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+	return r.Mod(r, f.p)
+}
+
+func (f *Prime) Inv(a *big.Int) (*big.Int, error) {
+	r := new(big.Int).ModInverse(a, f.p)
+	if r == nil {
+		return big.NewInt(0), ErrNoInverse
+	}
+	return r, nil
+}
+
+func (f *Prime) Equal(a, b *big.Int) bool {
+	if a.Cmp(b) == 0 {
+		return true
+	}
+	return f.Sub(a, b).Sign() == 0
+}
+
+func (f *Prime) IsZero(a *big.Int) bool {
+	if a.Sign() == 0 {
+		return true
+	}
+	return new(big.Int).Mod(a, f.p).Sign() == 0
+}
+
+func (f *Prime) Rand(r io.Reader) (*big.Int, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	return rand.Int(r, f.p)
+}
@@ -0,0 +1,171 @@
+package field
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	"math/bits"
+)
+
+// Prime64 is the prime field GF(p) for an odd prime p < 2^64. Its elements are
+// uint64 values in Montgomery form a*2^64 mod p, so Add, Sub, Mul and Inv
+// work on machine words and never allocate. Use FromUint64 and ToUint64 to
+// convert between residues and elements; the uint64 value of an element is
+// not its residue.
+type Prime64 struct {
+	p    uint64
+	pInv uint64 // -p^-1 mod 2^64
+	one  uint64 // 2^64 mod p
+	r2   uint64 // 2^128 mod p
+	r3   uint64 // 2^192 mod p
+}
+
+// NewPrime64 returns the field GF(p). p must be an odd prime.
+func NewPrime64(p uint64) (*Prime64, error) {
+	if p < 3 || p&1 == 0 || !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+		return nil, errors.New("modulus must be an odd prime")
+	}
+	// Newton's iteration doubles the number of correct low bits of p^-1
+	inv := p
+	for i := 0; i < 5; i++ {
+		inv *= 2 - p*inv
+	}
+	one := (-p) % p
+	f := &Prime64{
+		p:    p,
+		pInv: -inv,
+		one:  one,
+		r2:   bits.Rem64(one, 0, p),
+	}
+	f.r3 = f.Mul(f.r2, f.r2)
+	return f, nil
+}
+
+// Modulus returns p.
+func (f *Prime64) Modulus() uint64 {
+	return f.p
+}
+
+// reduce returns (hi*2^64 + lo) / 2^64 mod p for hi < p.
+func (f *Prime64) reduce(hi, lo uint64) uint64 {
+	m := lo * f.pInv
+	mHi, mLo := bits.Mul64(m, f.p)
+	_, carry := bits.Add64(lo, mLo, 0)
+	t, carry := bits.Add64(hi, mHi, carry)
+	if carry != 0 || t >= f.p {
+		t -= f.p
+	}
+	return t
+}
+
+// FromUint64 returns the element with residue v mod p.
+func (f *Prime64) FromUint64(v uint64) uint64 {
+	return f.Mul(v%f.p, f.r2)
+}
+
+// ToUint64 returns the residue of a in [0, p).
+func (f *Prime64) ToUint64(a uint64) uint64 {
+	return f.reduce(0, a)
+}
+
+func (f *Prime64) Order() *big.Int {
+	return new(big.Int).SetUint64(f.p)
+}
+
+func (f *Prime64) Characteristic() *big.Int {
+	return new(big.Int).SetUint64(f.p)
+}
+
+func (f *Prime64) Zero() uint64 {
+	return 0
+}
+
+func (f *Prime64) One() uint64 {
+	return f.one
+}
+
+func (f *Prime64) FromInt64(v int64) uint64 {
+	if v < 0 {
+		return f.Neg(f.FromUint64(uint64(-v)))
+	}
+	return f.FromUint64(uint64(v))
+}
+
+func (f *Prime64) FromBig(v *big.Int) uint64 {
+	r := new(big.Int).Mod(v, f.Order())
+	return f.FromUint64(r.Uint64())
+}
+
+func (f *Prime64) ToBig(a uint64) *big.Int {
+	return new(big.Int).SetUint64(f.ToUint64(a))
+}
+
+func (f *Prime64) Add(a, b uint64) uint64 {
+	s, carry := bits.Add64(a, b, 0)
+	if carry != 0 || s >= f.p {
+		s -= f.p
+	}
+	return s
+}
+
+func (f *Prime64) Sub(a, b uint64) uint64 {
+	d, borrow := bits.Sub64(a, b, 0)
+	if borrow != 0 {
+		d += f.p
+	}
+	return d
+}
+
+func (f *Prime64) Neg(a uint64) uint64 {
+	if a == 0 {
+		return 0
+	}
+	return f.p - a
+}
+
+func (f *Prime64) Mul(a, b uint64) uint64 {
+	return f.reduce(bits.Mul64(a, b))
+}
+
+// Inv returns 1/a using the extended Euclidean algorithm on machine words.
+func (f *Prime64) Inv(a uint64) (uint64, error) {
+	if a == 0 {
+		return 0, ErrNoInverse
+	}
+	// the cofactors of a alternate in sign and are bounded by p, so only
+	// their absolute values are kept
+	u, v := a, f.p
+	x0, x1 := uint64(1), uint64(0)
+	negative := false
+	for v != 0 {
+		q, r := u/v, u%v
+		u, v = v, r
+		x0, x1 = x1, x0+q*x1
+		negative = !negative
+	}
+	if negative {
+		x0 = f.p - x0
+	}
+	// a = bR, so x0 = 1/(bR) and 1/b is x0*R^2 in Montgomery form
+	return f.Mul(x0, f.r3), nil
+}
+
+func (f *Prime64) Equal(a, b uint64) bool {
+	return a == b
+}
+
+func (f *Prime64) IsZero(a uint64) bool {
+	return a == 0
+}
+
+func (f *Prime64) Rand(r io.Reader) (uint64, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	v, err := rand.Int(r, f.Order())
+	if err != nil {
+		return 0, err
+	}
+	return f.FromUint64(v.Uint64()), nil
+}
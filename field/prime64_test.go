@@ -0,0 +1,103 @@
+package field
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestPrime64(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, p := range []uint64{3, 65537, 1<<61 - 1, 1<<63 - 25, 1<<64 - 59} {
+		f, err := NewPrime64(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref := NewPrime(new(big.Int).SetUint64(p))
+		for i := 0; i < 200; i++ {
+			x, y := rnd.Uint64()%p, rnd.Uint64()%p
+			a, b := f.FromUint64(x), f.FromUint64(y)
+			bx, by := new(big.Int).SetUint64(x), new(big.Int).SetUint64(y)
+			if got := f.ToUint64(a); got != x {
+				t.Fatalf("p=%d: round trip of %d gave %d", p, x, got)
+			}
+			if f.ToBig(f.Add(a, b)).Cmp(ref.Add(bx, by)) != 0 {
+				t.Errorf("p=%d: %d + %d", p, x, y)
+			}
+			if f.ToBig(f.Sub(a, b)).Cmp(ref.Sub(bx, by)) != 0 {
+				t.Errorf("p=%d: %d - %d", p, x, y)
+			}
+			if f.ToBig(f.Mul(a, b)).Cmp(ref.Mul(bx, by)) != 0 {
+				t.Errorf("p=%d: %d * %d", p, x, y)
+			}
+			if x != 0 {
+				inv, err := f.Inv(a)
+				if err != nil || f.Mul(inv, a) != f.One() {
+					t.Errorf("p=%d: 1/%d", p, x)
+				}
+			}
+		}
+		if f.FromInt64(-1) != f.Neg(f.One()) || f.FromBig(big.NewInt(-1)) != f.Neg(f.One()) {
+			t.Errorf("p=%d: negative encodings", p)
+		}
+		if _, err := f.Inv(f.Zero()); err != ErrNoInverse {
+			t.Errorf("p=%d: expected ErrNoInverse, got %v", p, err)
+		}
+	}
+
+	for _, p := range []uint64{0, 2, 9, 1 << 40} {
+		if _, err := NewPrime64(p); err == nil {
+			t.Errorf("expected error for modulus %d", p)
+		}
+	}
+}
+
+func TestPrime64_Allocs(t *testing.T) {
+	f, _ := NewPrime64(1<<61 - 1)
+	a, b := f.FromUint64(12345), f.FromUint64(67890)
+	allocs := testing.AllocsPerRun(100, func() {
+		c := f.Add(a, b)
+		c = f.Mul(c, b)
+		c, _ = f.Inv(c)
+		a = f.Sub(c, a)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	p := uint64(1<<61 - 1)
+	b.Run("Prime", func(b *testing.B) {
+		f := NewPrime(new(big.Int).SetUint64(p))
+		x, y := f.FromInt64(123456789), f.FromInt64(987654321)
+		for i := 0; i < b.N; i++ {
+			x = f.Mul(x, y)
+		}
+	})
+	b.Run("Prime64", func(b *testing.B) {
+		f, _ := NewPrime64(p)
+		x, y := f.FromUint64(123456789), f.FromUint64(987654321)
+		for i := 0; i < b.N; i++ {
+			x = f.Mul(x, y)
+		}
+	})
+}
+
+func BenchmarkInv(b *testing.B) {
+	p := uint64(1<<61 - 1)
+	b.Run("Prime", func(b *testing.B) {
+		f := NewPrime(new(big.Int).SetUint64(p))
+		x := f.FromInt64(123456789)
+		for i := 0; i < b.N; i++ {
+			x, _ = f.Inv(x)
+		}
+	})
+	b.Run("Prime64", func(b *testing.B) {
+		f, _ := NewPrime64(p)
+		x := f.FromUint64(123456789)
+		for i := 0; i < b.N; i++ {
+			x, _ = f.Inv(x)
+		}
+	})
+}
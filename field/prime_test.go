@@ -0,0 +1,62 @@
+package field
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPrime(t *testing.T) {
+	f := NewPrime(big.NewInt(97))
+
+	a, b := f.FromInt64(-3), f.FromInt64(200)
+	if a.Int64() != 94 || b.Int64() != 6 {
+		t.Fatalf("expected 94 and 6, got %v and %v", a, b)
+	}
+	if got := f.Add(a, b); got.Int64() != 3 {
+		t.Errorf("expected 3, got %v", got)
+	}
+	if got := f.Sub(b, a); got.Int64() != 9 {
+		t.Errorf("expected 9, got %v", got)
+	}
+	inv, err := f.Inv(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Equal(f.Mul(inv, b), f.One()) {
+		t.Errorf("expected %v * %v = 1", inv, b)
+	}
+	if _, err := f.Inv(f.FromInt64(97)); err != ErrNoInverse {
+		t.Errorf("expected ErrNoInverse, got %v", err)
+	}
+	if !f.Equal(big.NewInt(100), big.NewInt(3)) || !f.IsZero(big.NewInt(194)) {
+		t.Errorf("unreduced inputs are not treated modulo p")
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	f := NewPrime(big.NewInt(101))
+	a := f.FromInt64(7)
+
+	if got := ExpInt[*big.Int](f, a, 100); got.Int64() != 1 {
+		t.Errorf("expected a^(p-1) = 1, got %v", got)
+	}
+	if got := MulInt[*big.Int](f, a, 15); got.Int64() != 4 {
+		t.Errorf("expected 15*7 = 4, got %v", got)
+	}
+	if got := MulInt[*big.Int](f, a, -1); got.Int64() != 94 {
+		t.Errorf("expected -7 = 94, got %v", got)
+	}
+	q, err := Div[*big.Int](f, f.One(), a)
+	if err != nil || f.Mul(q, a).Int64() != 1 {
+		t.Errorf("expected 1/7, got %v (%v)", q, err)
+	}
+	if !Same[*big.Int](f, NewPrime(big.NewInt(101))) || Same[*big.Int](f, NewPrime(big.NewInt(103))) {
+		t.Errorf("Same does not compare the order")
+	}
+	for i := 0; i < 10; i++ {
+		r, err := f.Rand(nil)
+		if err != nil || r.Sign() < 0 || r.Cmp(f.Order()) >= 0 {
+			t.Fatalf("random element out of range: %v (%v)", r, err)
+		}
+	}
+}
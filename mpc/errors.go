@@ -0,0 +1,12 @@
+package mpc
+
+import "errors"
+
+// errNoSharings is returned if no sharings are given.
+var errNoSharings = errors.New("no sharings given")
+
+// errSchemeMismatch is returned if shares of different schemes are combined.
+var errSchemeMismatch = errors.New("shares belong to different schemes")
+
+// errIndexMismatch is returned if shares of different parties are combined.
+var errIndexMismatch = errors.New("shares of different parties combined")
@@ -0,0 +1,169 @@
+package mpc
+
+import (
+	"errors"
+	"fmt"
+
+	"oec/shamir"
+	"oec/utils"
+)
+
+// Multiplier multiplies shared values with the protocol of Ben-Or, Goldwasser
+// and Wigderson in the simplification of Gennaro, Rabin and Rabin. The
+// product of two shares of degree-t sharings is a share of a degree-2t
+// sharing of the product. To bring the degree back to t, every party reshares
+// its product share with a fresh degree-t sharing, and every party combines
+// the sub-shares it receives with the Lagrange recombination vector, which
+// maps the values at the points of the parties of any polynomial of degree
+// <= 2t to its value at 0.
+type Multiplier[E any] struct {
+	s      *shamir.Scheme[E]
+	double *shamir.Scheme[E]
+	lambda []E
+}
+
+// NewMultiplier returns the multiplier for the sharings of s. It requires
+// n >= 2t+1, and n >= 4t+1 to correct t wrong product shares.
+func NewMultiplier[E any](s *shamir.Scheme[E]) (*Multiplier[E], error) {
+	if s.Packing() != 1 {
+		return nil, errors.New("cannot multiply packed sharings")
+	}
+	double, err := shamir.NewScheme(2*s.Threshold(), s.Parties(), s.Field())
+	if err != nil {
+		return nil, err
+	}
+	xs := make([]E, s.Parties())
+	for i := range xs {
+		xs[i] = s.Point(i + 1)
+	}
+	lambda, err := utils.LagrangeCoefficients(s.Field(), xs, s.Field().Zero())
+	if err != nil {
+		return nil, err
+	}
+	return &Multiplier[E]{s: s, double: double, lambda: lambda}, nil
+}
+
+// Scheme returns the scheme of the factors and of the reduced products.
+func (m *Multiplier[E]) Scheme() *shamir.Scheme[E] {
+	return m.s
+}
+
+// Double returns the scheme with threshold 2t of the local products.
+func (m *Multiplier[E]) Double() *shamir.Scheme[E] {
+	return m.double
+}
+
+// Recombination returns the recombination vector of all n parties: the value
+// at 0 of a polynomial h of degree < n is sum_i lambda[i-1] h(x_i).
+func (m *Multiplier[E]) Recombination() []E {
+	return append([]E{}, m.lambda...)
+}
+
+// Mul returns the local product of two shares of the same party, a share of
+// a degree-2t sharing of the product.
+func (m *Multiplier[E]) Mul(a, b shamir.Share[E]) (shamir.Share[E], error) {
+	if a.Scheme() != m.s || b.Scheme() != m.s {
+		return shamir.Share[E]{}, errSchemeMismatch
+	}
+	if a.Index != b.Index {
+		return shamir.Share[E]{}, errIndexMismatch
+	}
+	return m.double.NewShare(a.Index, m.s.Field().Mul(a.Value, b.Value))
+}
+
+// Reduce returns the sub-shares a party sends to the n parties to reduce the
+// degree of its product share: a fresh degree-t sharing of its value.
+func (m *Multiplier[E]) Reduce(product shamir.Share[E]) ([]shamir.SubShare[E], error) {
+	if product.Scheme() != m.double {
+		return nil, errSchemeMismatch
+	}
+	shares, err := m.s.Split(product.Value)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]shamir.SubShare[E], len(shares))
+	for j, sh := range shares {
+		out[j] = shamir.SubShare[E]{From: product.Index, To: sh.Index, Value: sh.Value}
+	}
+	return out, nil
+}
+
+// Recombine returns the share of party index of the degree-t sharing of the
+// product, combined from the sub-shares it received. Sub-shares from all n
+// parties use the precomputed recombination vector; otherwise at least 2t+1
+// are needed and the vector is computed for their senders. A wrong sub-share
+// goes undetected and shifts the product; against corrupted parties, reduce
+// with Reshare from Double to Scheme, which decodes the sub-shares.
+func (m *Multiplier[E]) Recombine(index int, subShares []shamir.SubShare[E]) (shamir.Share[E], error) {
+	f := m.s.Field()
+	if len(subShares) < m.double.Degree()+1 {
+		return shamir.Share[E]{}, fmt.Errorf("degree reduction needs %d sub-shares, got %d", m.double.Degree()+1, len(subShares))
+	}
+	xs := make([]E, len(subShares))
+	seen := make(map[int]bool, len(subShares))
+	for i, sub := range subShares {
+		if sub.To != index {
+			return shamir.Share[E]{}, fmt.Errorf("sub-share for party %d given to party %d", sub.To, index)
+		}
+		if sub.From < 1 || sub.From > m.s.Parties() || seen[sub.From] {
+			return shamir.Share[E]{}, fmt.Errorf("invalid sub-share from party %d", sub.From)
+		}
+		seen[sub.From] = true
+		xs[i] = m.s.Point(sub.From)
+	}
+	var lambda []E
+	if len(subShares) == m.s.Parties() {
+		lambda = make([]E, len(subShares))
+		for i, sub := range subShares {
+			lambda[i] = m.lambda[sub.From-1]
+		}
+	} else {
+		var err error
+		if lambda, err = utils.LagrangeCoefficients(f, xs, f.Zero()); err != nil {
+			return shamir.Share[E]{}, err
+		}
+	}
+	v := f.Zero()
+	for i, sub := range subShares {
+		v = f.Add(v, f.Mul(lambda[i], sub.Value))
+	}
+	return m.s.NewShare(index, v)
+}
+
+// Open robustly reconstructs the product from the local products of the
+// parties, correcting up to (m-2t-1)/2 wrong ones among m. It also returns
+// the parties that sent those.
+func (m *Multiplier[E]) Open(products []shamir.Share[E]) (E, []int, error) {
+	return m.double.RobustReconstruct(products)
+}
+
+// Simulate runs the multiplication of the sharings a and b among the n
+// parties in one process. a and b hold the shares of all n parties in the
+// same order; the result holds the shares of the product in that order.
+func (m *Multiplier[E]) Simulate(a, b []shamir.Share[E]) ([]shamir.Share[E], error) {
+	if len(a) != m.s.Parties() || len(b) != len(a) {
+		return nil, fmt.Errorf("simulation needs the shares of all %d parties", m.s.Parties())
+	}
+	received := make(map[int][]shamir.SubShare[E], len(a))
+	for i := range a {
+		product, err := m.Mul(a[i], b[i])
+		if err != nil {
+			return nil, err
+		}
+		subShares, err := m.Reduce(product)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range subShares {
+			received[sub.To] = append(received[sub.To], sub)
+		}
+	}
+	out := make([]shamir.Share[E], len(a))
+	for i, sh := range a {
+		var err error
+		if out[i], err = m.Recombine(sh.Index, received[sh.Index]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
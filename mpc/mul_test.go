@@ -0,0 +1,109 @@
+package mpc
+
+import (
+	"testing"
+
+	"oec/field"
+	"oec/shamir"
+)
+
+func TestMultiplier(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	s, _ := shamir.NewScheme[uint64](2, 9, f)
+	m, err := NewMultiplier(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := f.FromInt64(6), f.FromInt64(7)
+	a, _ := s.Split(x)
+	b, _ := s.Split(y)
+
+	c, err := m.Simulate(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, faulty, err := s.RobustReconstruct(c)
+	if err != nil || len(faulty) != 0 {
+		t.Fatalf("reduced product: %v, faulty %v", err, faulty)
+	}
+	if got != f.FromInt64(42) {
+		t.Errorf("product %d, want 42", got)
+	}
+	// the reduced sharing multiplies again
+	d, err := m.Simulate(c, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Reconstruct(d); got != f.FromInt64(252) {
+		t.Errorf("product %d, want 252", got)
+	}
+
+	// parties 2 and 7 send garbage local products
+	products := make([]shamir.Share[uint64], len(a))
+	for i := range a {
+		if products[i], err = m.Mul(a[i], b[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	products[1].Value = f.Add(products[1].Value, f.One())
+	products[6].Value = f.FromInt64(13)
+	got, faulty, err = m.Open(products)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != f.FromInt64(42) {
+		t.Errorf("opened product %d, want 42", got)
+	}
+	if len(faulty) != 2 || faulty[0] != 2 || faulty[1] != 7 {
+		t.Errorf("expected faulty parties [2 7], got %v", faulty)
+	}
+
+	if _, err := m.Mul(a[0], b[1]); err == nil {
+		t.Errorf("multiplied shares of different parties")
+	}
+}
+
+func TestRecombine(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	s, _ := shamir.NewScheme[uint64](1, 4, f)
+	m, _ := NewMultiplier(s)
+	sum := f.Zero()
+	for _, l := range m.Recombination() {
+		sum = f.Add(sum, l)
+	}
+	if sum != f.One() {
+		t.Errorf("recombination vector sums to %d, want 1", sum)
+	}
+
+	a, _ := s.Split(f.FromInt64(5))
+	b, _ := s.Split(f.FromInt64(9))
+	received := make([][]shamir.SubShare[uint64], s.Parties())
+	for i := range a {
+		product, _ := m.Mul(a[i], b[i])
+		subShares, err := m.Reduce(product)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sub := range subShares {
+			received[sub.To-1] = append(received[sub.To-1], sub)
+		}
+	}
+	// party 4 does not reshare: the others recombine the sub-shares of 2t+1
+	// parties
+	c := make([]shamir.Share[uint64], s.Parties())
+	for j := range c {
+		var err error
+		if c[j], err = m.Recombine(j+1, received[j][:3]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := s.Reconstruct(c); got != f.FromInt64(45) {
+		t.Errorf("product %d, want 45", got)
+	}
+	if _, err := m.Recombine(1, received[0][:2]); err == nil {
+		t.Errorf("recombined 2t sub-shares")
+	}
+	if _, err := m.Recombine(2, received[0]); err == nil {
+		t.Errorf("recombined sub-shares of another party")
+	}
+}
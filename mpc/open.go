@@ -0,0 +1,125 @@
+package mpc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"oec/reedsolomonP"
+	"oec/shamir"
+)
+
+// ExpandBatch is the local first step of the batch public reconstruction of
+// Damgård and Nielsen. Given up to t+1 sharings of x_1, x_2, ..., with
+// sharings[d] holding shares at the same positions, it returns the n
+// sharings of y_j = g(x_j) for the polynomial g(X) = x_1 + x_2 X + ... and
+// the point x_j of party j. expanded[j-1] is opened privately towards party
+// j, which reconstructs y_j with RobustReconstruct and sends it to all
+// parties; every party then decodes the x_d from the y_j with DecodeBatch.
+//
+// Each party sends one share to every party and one value to every party,
+// O(n^2) field elements for t+1 opened values, where opening every sharing
+// with RobustReconstruct costs O(n^2) per value.
+func ExpandBatch[E any](sharings [][]shamir.Share[E]) ([][]shamir.Share[E], error) {
+	s, err := check(sharings)
+	if err != nil {
+		return nil, err
+	}
+	if s.Packing() != 1 {
+		return nil, errors.New("cannot open packed sharings in a batch")
+	}
+	if len(sharings) > s.Threshold()+1 {
+		return nil, fmt.Errorf("a batch holds at most %d sharings, got %d", s.Threshold()+1, len(sharings))
+	}
+	xs := make([]E, s.Parties())
+	for j := range xs {
+		xs[j] = s.Point(j + 1)
+	}
+	m, err := reedsolomonP.Vandermonde(s.Field(), xs, len(sharings))
+	if err != nil {
+		return nil, err
+	}
+	return combine(s, m, sharings)
+}
+
+// DecodeBatch returns the t+1 values x_1, ..., x_(t+1) of a batch from the
+// values y_j the parties reconstructed, values[i] holding y_Index as sent by
+// party Index. Values of a batch of fewer than t+1 sharings end in zeros.
+// With m values, up to (m-t-1)/2 of them may be wrong; the parties that sent
+// those are returned as well.
+func DecodeBatch[E any](s *shamir.Scheme[E], values []shamir.Share[E]) ([]E, []int, error) {
+	if s.Packing() != 1 {
+		return nil, nil, errors.New("cannot open packed sharings in a batch")
+	}
+	received := make([]reedsolomonP.ShareOf[E], len(values))
+	for i, v := range values {
+		if v.Scheme() != s {
+			return nil, nil, errSchemeMismatch
+		}
+		received[i] = reedsolomonP.ShareOf[E]{Number: v.Index - 1, Data: v.Value}
+	}
+	result, err := s.Code().Decode(received)
+	if err != nil {
+		return nil, nil, err
+	}
+	xs := make([]E, s.Threshold()+1)
+	for d := range xs {
+		xs[d] = s.Field().Zero()
+		if d < len(result.Message) {
+			xs[d] = result.Message[d]
+		}
+	}
+	faulty := make([]int, len(result.Faulty))
+	for i, number := range result.Faulty {
+		faulty[i] = number + 1
+	}
+	return xs, faulty, nil
+}
+
+// OpenBatch simulates the batch public reconstruction of any number of
+// sharings in one process, in batches of t+1. sharings[d] holds the shares
+// of the d-th sharing, at the same positions for every d. It returns the
+// opened values and the parties found to hold wrong shares.
+func OpenBatch[E any](sharings [][]shamir.Share[E]) ([]E, []int, error) {
+	s, err := check(sharings)
+	if err != nil {
+		return nil, nil, err
+	}
+	size := s.Threshold() + 1
+	opened := make([]E, 0, len(sharings))
+	found := make(map[int]bool)
+	for start := 0; start < len(sharings); start += size {
+		batch := sharings[start:min(start+size, len(sharings))]
+		expanded, err := ExpandBatch(batch)
+		if err != nil {
+			return nil, nil, err
+		}
+		values := make([]shamir.Share[E], len(expanded))
+		for j, sharing := range expanded {
+			y, faulty, err := s.RobustReconstruct(sharing)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, i := range faulty {
+				found[i] = true
+			}
+			if values[j], err = s.NewShare(j+1, y); err != nil {
+				return nil, nil, err
+			}
+		}
+		xs, faulty, err := DecodeBatch(s, values)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, i := range faulty {
+			found[i] = true
+		}
+		opened = append(opened, xs[:len(batch)]...)
+	}
+	faulty := make([]int, 0, len(found))
+	for i := range found {
+		faulty = append(faulty, i)
+	}
+	sort.Ints(faulty)
+	return opened, faulty, nil
+}
@@ -0,0 +1,80 @@
+package mpc
+
+import (
+	"testing"
+
+	"oec/field"
+	"oec/shamir"
+)
+
+func TestOpenBatch(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	s, _ := shamir.NewScheme[uint64](2, 7, f)
+	secrets := make([]uint64, 8)
+	sharings := make([][]shamir.Share[uint64], len(secrets))
+	for d := range sharings {
+		secrets[d] = f.FromInt64(int64(100 + d))
+		var err error
+		if sharings[d], err = s.Split(secrets[d]); err != nil {
+			t.Fatal(err)
+		}
+		// party 5 holds garbage
+		sharings[d][4].Value = f.Add(sharings[d][4].Value, f.FromInt64(int64(d+1)))
+	}
+	opened, faulty, err := OpenBatch(sharings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opened) != len(secrets) {
+		t.Fatalf("opened %d values, want %d", len(opened), len(secrets))
+	}
+	for d, v := range opened {
+		if v != secrets[d] {
+			t.Errorf("opened %d as %d, want %d", d, v, secrets[d])
+		}
+	}
+	if len(faulty) != 1 || faulty[0] != 5 {
+		t.Errorf("expected faulty party [5], got %v", faulty)
+	}
+}
+
+func TestDecodeBatch(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	s, _ := shamir.NewScheme[uint64](1, 4, f)
+	a, _ := s.Split(f.FromInt64(3))
+	b, _ := s.Split(f.FromInt64(4))
+	expanded, err := ExpandBatch([][]shamir.Share[uint64]{a, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// party j reconstructs y_j = 3 + 4j and party 2 sends a wrong value
+	values := make([]shamir.Share[uint64], len(expanded))
+	for j, sharing := range expanded {
+		y, err := s.Reconstruct(sharing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := f.FromInt64(int64(3 + 4*(j+1))); y != want {
+			t.Errorf("y_%d = %d, want %d", j+1, y, want)
+		}
+		if j == 1 {
+			y = f.Add(y, f.One())
+		}
+		values[j], _ = s.NewShare(j+1, y)
+	}
+	xs, faulty, err := DecodeBatch(s, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xs[0] != f.FromInt64(3) || xs[1] != f.FromInt64(4) {
+		t.Errorf("decoded %v, want [3 4]", xs)
+	}
+	if len(faulty) != 1 || faulty[0] != 2 {
+		t.Errorf("expected faulty party [2], got %v", faulty)
+	}
+
+	c, _ := s.Split(f.FromInt64(5))
+	if _, err := ExpandBatch([][]shamir.Share[uint64]{a, b, c}); err == nil {
+		t.Errorf("expanded a batch of t+2 sharings")
+	}
+}
@@ -0,0 +1,96 @@
+// Package mpc implements building blocks of information-theoretic multiparty
+// computation on the Shamir sharings of package shamir: extraction of random
+// sharings, batch public reconstruction and multiplication.
+package mpc
+
+import (
+	"fmt"
+
+	"oec/reedsolomonP"
+	"oec/shamir"
+)
+
+// ExtractRandomness turns the n sharings dealt by the n parties of a scheme
+// with threshold t into n-2t sharings of uniformly random values that no t
+// parties know, as in the preprocessing of Beerliová-Hirt. shares[d] holds
+// shares of the sharing dealt by party d+1: all of them, or only those of
+// one party, which then computes its own shares of the output. The output
+// holds the shares at the same positions.
+//
+// The output is M * (s_1, ..., s_n) for the first n-2t rows M of the n x n
+// hyper-invertible matrix with inputs 1, ..., n and outputs n+1, ..., 2n.
+// With at most t dealers corrupted, the n-2t outputs together with the t
+// corrupted inputs determine the n-t honest inputs bijectively, so they are
+// uniform even given the corrupted inputs. The protocol of Beerliová-Hirt
+// also opens the other 2t outputs to check that every dealt sharing has
+// degree t; that check is left to the caller.
+func ExtractRandomness[E any](shares [][]shamir.Share[E]) ([][]shamir.Share[E], error) {
+	s, err := check(shares)
+	if err != nil {
+		return nil, err
+	}
+	n, t := s.Parties(), s.Threshold()
+	if len(shares) != n {
+		return nil, fmt.Errorf("extraction needs the sharings of all %d parties, got %d", n, len(shares))
+	}
+	if n <= 2*t {
+		return nil, fmt.Errorf("extraction requires n > 2t, got n = %d and t = %d", n, t)
+	}
+	f := s.Field()
+	xs := make([]E, 2*n-2*t)
+	for i := range xs {
+		xs[i] = f.FromInt64(int64(i + 1))
+	}
+	m, err := reedsolomonP.HyperInvertible(f, xs[:n], xs[n:])
+	if err != nil {
+		return nil, err
+	}
+	return combine(s, m, shares)
+}
+
+// check verifies that shares[d] hold shares of the same scheme at the same
+// positions for every d, and returns the scheme.
+func check[E any](shares [][]shamir.Share[E]) (*shamir.Scheme[E], error) {
+	if len(shares) == 0 || len(shares[0]) == 0 {
+		return nil, errNoSharings
+	}
+	s := shares[0][0].Scheme()
+	if s == nil {
+		return nil, errSchemeMismatch
+	}
+	for _, sharing := range shares {
+		if len(sharing) != len(shares[0]) {
+			return nil, errIndexMismatch
+		}
+		for i, sh := range sharing {
+			if sh.Scheme() != s {
+				return nil, errSchemeMismatch
+			}
+			if sh.Index != shares[0][i].Index {
+				return nil, errIndexMismatch
+			}
+		}
+	}
+	return s, nil
+}
+
+// combine returns the sharings m * shares, computed locally at every
+// position of the shares.
+func combine[E any](s *shamir.Scheme[E], m reedsolomonP.MatrixOf[E], shares [][]shamir.Share[E]) ([][]shamir.Share[E], error) {
+	f := s.Field()
+	out := make([][]shamir.Share[E], len(m))
+	for k, row := range m {
+		out[k] = make([]shamir.Share[E], len(shares[0]))
+		for i, sh := range shares[0] {
+			v := f.Zero()
+			for d, c := range row {
+				v = f.Add(v, f.Mul(c, shares[d][i].Value))
+			}
+			var err error
+			if out[k][i], err = s.NewShare(sh.Index, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
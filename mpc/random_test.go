@@ -0,0 +1,78 @@
+package mpc
+
+import (
+	"testing"
+
+	"oec/field"
+	"oec/reedsolomonP"
+	"oec/shamir"
+)
+
+func TestExtractRandomness(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	s, _ := shamir.NewScheme[uint64](2, 7, f)
+	n := s.Parties()
+	secrets := make([]uint64, n)
+	dealt := make([][]shamir.Share[uint64], n)
+	for d := range dealt {
+		secrets[d], _ = f.Rand(nil)
+		var err error
+		if dealt[d], err = s.Split(secrets[d]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	random, err := ExtractRandomness(dealt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(random) != n-2*s.Threshold() {
+		t.Fatalf("extracted %d sharings, want %d", len(random), n-2*s.Threshold())
+	}
+
+	xs := make([]uint64, 2*n)
+	for i := range xs {
+		xs[i] = f.FromInt64(int64(i + 1))
+	}
+	m, _ := reedsolomonP.HyperInvertible[uint64](f, xs[:n], xs[n:])
+	for k, sharing := range random {
+		got, faulty, err := s.RobustReconstruct(sharing)
+		if err != nil || len(faulty) != 0 {
+			t.Fatalf("sharing %d: %v, faulty %v", k, err, faulty)
+		}
+		want := f.Zero()
+		for d, c := range m[k] {
+			want = f.Add(want, f.Mul(c, secrets[d]))
+		}
+		if got != want {
+			t.Errorf("sharing %d holds %d, want %d", k, got, want)
+		}
+	}
+
+	// every party extracts its own shares locally
+	for i := 0; i < n; i++ {
+		local := make([][]shamir.Share[uint64], n)
+		for d := range dealt {
+			local[d] = dealt[d][i : i+1]
+		}
+		own, err := ExtractRandomness(local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k := range own {
+			if own[k][0] != random[k][i] {
+				t.Errorf("party %d computed share %v of sharing %d, want %v", i+1, own[k][0], k, random[k][i])
+			}
+		}
+	}
+
+	if _, err := ExtractRandomness(dealt[1:]); err == nil {
+		t.Errorf("extracted from n-1 sharings")
+	}
+	local := [][]shamir.Share[uint64]{dealt[0][:1]}
+	for d := 1; d < n; d++ {
+		local = append(local, dealt[d][1:2])
+	}
+	if _, err := ExtractRandomness(local); err == nil {
+		t.Errorf("combined shares of different parties")
+	}
+}
@@ -1,292 +1,174 @@
 package reedsolomonP
 
 import (
-	"errors"
 	"fmt"
-	"math/big"
 	"sort"
-)
-
-// modAdd 在模p下执行加法
-func modAdd(a, b, p *big.Int) *big.Int {
-	result := new(big.Int).Add(a, b)
-	result.Mod(result, p)
-	return result
-}
-
-// modSub 在模p下执行减法
-func modSub(a, b, p *big.Int) *big.Int {
-	result := new(big.Int).Sub(a, b)
-	result.Mod(result, p)
-	return result
-
-}
 
-// modMul 在模p下执行乘法
-func modMul(a, b, p *big.Int) *big.Int {
-	result := new(big.Int).Mul(a, b)
-	result.Mod(result, p)
-	return result
-}
+	"oec/utils"
+)
 
-// modInverse computes the modular multiplicative inverse of a under modulo p.
-func modInverse(a, p *big.Int) (*big.Int, error) {
-	inv := new(big.Int).ModInverse(a, p)
-	if inv == nil {
-		return nil, fmt.Errorf("no inverse exists for %s in Z_%s", a, p)
+// BerlekampWelch corrects up to e errors in the shares using the Berlekamp-Welch
+// algorithm. At least k+2e shares are required; all of them are used as
+// equations, so the call fails unless at most e of them are wrong.
+func (fc *RSGF[E]) BerlekampWelch(shares []ShareOf[E], e int) ([]ShareOf[E], error) {
+	pPoly, err := fc.berlekampWelch(shares, e)
+	if err != nil {
+		return nil, err
 	}
-	return inv, nil
+	return fc.evaluate(pPoly)
 }
 
-// modPow computes a^b modPow p.
-func modPow(a, b, p *big.Int) *big.Int {
-	return new(big.Int).Exp(a, b, p)
-}
-
-func evalPoint(num int, p *big.Int) *big.Int {
-	if num == 0 {
-		return BigZero
+// berlekampWelch returns the coefficients of the message polynomial P with
+// deg P < k that agrees with all but at most e of the shares.
+func (fc *RSGF[E]) berlekampWelch(shares []ShareOf[E], e int) ([]E, error) {
+	k := fc.k
+	f := fc.f
+	if e < 0 || len(shares) < k+2*e {
+		return nil, errTooFewShards
 	}
-	return modPow(BigTwo, new(big.Int).SetInt64(int64(num-1)), p)
-}
 
-// invertMatrix 矩阵求逆
-func invertMatrix(s, a [][]*big.Int, p *big.Int) error {
-	n := len(s)
-	for i := 0; i < n; i++ {
-		pivot := s[i][i]
-		if pivot.Sign() == 0 {
-			return errors.New("matrix is singular")
-		}
-		pivotInv, err := modInverse(pivot, p)
-		if err != nil {
-			return err
-		}
-		for j := 0; j < n; j++ {
-			s[i][j] = modMul(s[i][j], pivotInv, p)
-			a[i][j] = modMul(a[i][j], pivotInv, p)
-		}
-		for j := 0; j < n; j++ {
-			if i != j {
-				factor := s[j][i]
-				for k := 0; k < n; k++ {
-					s[j][k] = modSub(s[j][k], modMul(factor, s[i][k], p), p)
-					a[j][k] = modSub(a[j][k], modMul(factor, a[i][k], p), p)
-				}
+	// Q(x) has degree k+e-1 and E(x) is monic of degree e, so for every share
+	// Q(x_i) - y_i*(E(x_i) - x_i^e) = y_i*x_i^e.
+	// The unknowns are Q_0..Q_{k+e-1} followed by E_0..E_{e-1}.
+	s := make(MatrixOf[E], len(shares))
+	b := make([]E, len(shares))
+	for i, share := range shares {
+		x := fc.point(share.Number)
+		y := share.Data
+		s[i] = make([]E, k+2*e)
+		xj := f.One()
+		for j := 0; j < k+e; j++ {
+			s[i][j] = xj
+			if j < e {
+				s[i][k+e+j] = f.Neg(f.Mul(y, xj))
+			}
+			if j == e {
+				b[i] = f.Mul(y, xj)
 			}
+			xj = f.Mul(xj, x)
 		}
 	}
-	return nil
-}
-
-// dotProduct 计算两个向量的点积
-func dotProduct(a, b []*big.Int, p *big.Int) *big.Int {
-	result := big.NewInt(0)
-	for i := range a {
-		result.Add(result, modMul(a[i], b[i], p))
+	u, err := s.Solve(f, b)
+	if err != nil {
+		return nil, tooManyErrors
 	}
-	return result.Mod(result, p)
-}
 
-// evalPoly 评估多项式在某个点的值
-func evalPoly(poly []*big.Int, x, p *big.Int) *big.Int {
-	result := big.NewInt(0)
-	for i := len(poly) - 1; i >= 0; i-- {
-		result.Mul(result, x)
-		result.Add(result, poly[i])
-		result.Mod(result, p)
-	}
-	return result
-}
+	qPoly := utils.NewPolyOf(f, u[:k+e]...)
+	ePoly := utils.NewPolyOf(f, append(append([]E{}, u[k+e:]...), f.One())...)
 
-// divPolynomials 多项式除法
-func divPolynomials(A, B []*big.Int, p *big.Int) ([]*big.Int, []*big.Int, error) {
-	n := len(A)
-	m := len(B)
-	if m == 0 {
-		return nil, nil, errors.New("division by zero")
+	pPoly, rem, err := qPoly.DivMod(ePoly)
+	if err != nil {
+		return nil, err
 	}
-	if n < m {
-		return []*big.Int{big.NewInt(0)}, A, nil
+	if !rem.IsZero() || pPoly.Degree() >= k {
+		return nil, tooManyErrors
 	}
+	return pPoly.Coefficients(k), nil
+}
 
-	Q := make([]*big.Int, n-m+1)
-	R := make([]*big.Int, len(A))
-	for i := range A {
-		R[i] = new(big.Int).Set(A[i])
-	}
+// point returns the evaluation point of the share with the given number.
+func (fc *RSGF[E]) point(number int) E {
+	return fc.xs[number]
+}
 
-	for i := n - m; i >= 0; i-- {
-		q, err := modInverse(B[m-1], p)
-		if err != nil {
-			return nil, nil, err
-		}
-		q = modMul(R[i+m-1], q, p)
-		Q[i] = new(big.Int).Set(q)
-		for j := 0; j < m; j++ {
-			R[i+j] = modSub(R[i+j], modMul(q, B[j], p), p)
+// agreement counts the shares that lie on the polynomial poly.
+func (fc *RSGF[E]) agreement(shares []ShareOf[E], poly []E) int {
+	p := utils.NewPolyOf(fc.f, poly...)
+	count := 0
+	for _, share := range shares {
+		if fc.f.Equal(p.Eval(fc.point(share.Number)), share.Data) {
+			count++
 		}
 	}
+	return count
+}
 
-	return Q, R, nil
+// Correct corrects the errors in the shares using the configured decoder.
+// Shares with nil Data are treated as erasures.
+func (fc *RSGF[E]) Correct(shares []ShareOf[E]) ([]ShareOf[E], error) {
+	return fc.CorrectWithErasures(shares, nil)
 }
 
-// isZero 检查多项式是否为零
-func isZero(poly []*big.Int) bool {
-	for _, c := range poly {
-		if c.Sign() != 0 {
-			return false
-		}
+// CorrectWithErasures corrects the shares knowing that the shares listed in
+// erasures, those with nil Data and those missing from shares are lost rather
+// than corrupted. Decoding succeeds as long as 2e + s <= n - k, where s is the
+// number of erasures and e the number of wrong shares among the rest.
+func (fc *RSGF[E]) CorrectWithErasures(shares []ShareOf[E], erasures []int) ([]ShareOf[E], error) {
+	result, err := fc.DecodeWithErasures(shares, erasures)
+	if err != nil {
+		return nil, err
 	}
-	return true
+	return result.Codeword, nil
 }
 
-// BerlekampWelch corrects errors in the data using the Berlekamp-Welch algorithm.
-func (fc *RSGFp) BerlekampWelch(shares []Share, e int) ([]Share, error) {
-	k := fc.k // required size
-
-	q := e + k - 1 // def of Q polynomial
-	fmt.Printf("e=%v, q=%v\n", e, q)
-	if e <= 0 {
-		return nil, errTooFewShards
-	}
-	dim := q + e + 2
-	// build the system of equations s * u = f
-	s := make([][]*big.Int, dim)
-	a := make([][]*big.Int, dim)
-	for i := range s {
-		s[i] = make([]*big.Int, dim)
-	}
-	for i := range s {
-		a[i] = make([]*big.Int, dim)
-	}
-	f := make([]*big.Int, dim)
-	u := make([]*big.Int, dim)
-	for i := range f {
-		f[i] = big.NewInt(0)
-	}
-	f[dim-1] = big.NewInt(1)
-	for i := 0; i < dim-1; i++ {
-		x_i := new(big.Int).SetInt64(int64(shares[i].Number + 1))
-		r_i := shares[i].Data
-		for j := 0; j < q+1; j++ {
-			s[i][j] = modPow(x_i, big.NewInt(int64(j)), fc.p)
-			//s[i][j] = modSub(BigZero, s[i][j], fc.p)
-			if i == j {
-				a[i][j] = big.NewInt(1)
-			} else {
-				a[i][j] = big.NewInt(0)
-			}
-		}
+// Decode decodes the shares like Correct and reports the message, the
+// corrected codeword and the shares that were found to be wrong.
+func (fc *RSGF[E]) Decode(shares []ShareOf[E]) (*CorrectionResultOf[E], error) {
+	return fc.DecodeWithErasures(shares, nil)
+}
 
-		for l := 0; l < e+1; l++ {
-			j := l + q + 1
-			s[i][j] = modMul(modPow(x_i, big.NewInt(int64(l)), fc.p), r_i, fc.p)
-			if i == j {
-				a[i][j] = big.NewInt(1)
-			} else {
-				a[i][j] = big.NewInt(0)
-			}
-		}
-	}
-	for i := 0; i < dim; i++ {
-		s[dim-1][i] = BigZero
-		a[dim-1][i] = BigZero
-	}
-	s[dim-1][dim-1] = BigOne
-	a[dim-1][dim-1] = BigOne
-	// invert and put the result in a
-	err := invertMatrix(s, a, fc.p)
+// DecodeWithErasures decodes the shares like CorrectWithErasures and reports
+// the message, the corrected codeword and the shares that were found to be
+// wrong. Erased shares are not reported as faulty.
+func (fc *RSGF[E]) DecodeWithErasures(shares []ShareOf[E], erasures []int) (*CorrectionResultOf[E], error) {
+	k := fc.k
+	kept, err := fc.dropErasures(shares, erasures)
 	if err != nil {
 		return nil, err
 	}
-	// multiply the inverted matrix by the column vector
-	for i := 0; i < dim; i++ {
-		ri := a[i]
-		u[i] = dotProduct(ri, f, fc.p)
+	r := len(kept)
+	if r < k {
+		return nil, errTooFewShards
 	}
 
-	// reverse u for easier construction of the polynomials
-	for i := 0; i < len(u)/2; i++ {
-		o := len(u) - i - 1
-		u[i], u[o] = u[o], u[i]
+	// Sort the shares by their number
+	sort.Sort(byNumber[E](kept))
+
+	var pPoly []E
+	switch fc.decoder {
+	case GaoDecoder:
+		pPoly, err = fc.gaoDecode(kept)
+	case SyndromeDecoder:
+		pPoly, _, err = fc.syndromeDecode(kept)
+	case SugiyamaDecoder:
+		pPoly, _, err = fc.sugiyamaDecode(kept)
+	default:
+		// the n-r erased shares leave a code of length r that corrects (r-k)/2 errors
+		err = tooManyErrors
+		for e := 0; e <= (r-k)/2 && err != nil; e++ {
+			pPoly, err = fc.berlekampWelch(kept, e)
+		}
 	}
-
-	qPoly := u[e+1:]
-	// E(x) is monic polynomial
-	ePoly := u[:e+1]
-
-	pPoly, rem, err := divPolynomials(qPoly, ePoly, fc.p)
 	if err != nil {
 		return nil, err
 	}
+	return fc.newResult(kept, pPoly)
+}
 
-	if !isZero(rem) {
-		return nil, tooManyErrors
+// dropErasures returns the shares that are neither listed in erasures nor
+// have nil Data.
+func (fc *RSGF[E]) dropErasures(shares []ShareOf[E], erasures []int) ([]ShareOf[E], error) {
+	erased := make(map[int]bool, len(erasures))
+	for _, number := range erasures {
+		if number < 0 || number >= fc.n {
+			return nil, fmt.Errorf("invalid share id: %d", number)
+		}
+		erased[number] = true
 	}
-
-	out := make([]Share, fc.n)
-	for i := 0; i < fc.n; i++ {
-		fecBuf := new(big.Int).SetInt64(0)
-		for j := 0; j < fc.k; j++ {
-			fecBuf = new(big.Int).Add(fecBuf, new(big.Int).Mul(pPoly[k-j-1], fc.encMatrix[i][j]))
-			fecBuf.Mod(fecBuf, fc.p)
+	seen := make(map[int]bool, len(shares))
+	kept := make([]ShareOf[E], 0, len(shares))
+	for _, share := range shares {
+		if share.Number < 0 || share.Number >= fc.n {
+			return nil, fmt.Errorf("invalid share id: %d", share.Number)
 		}
-
-		out[i] = Share{
-			Number: i,
-			Data:   fecBuf,
+		if seen[share.Number] {
+			return nil, errDuplicateShare
 		}
-	}
-	return out, nil
-}
-
-// Correct corrects the errors in the shares using the Berlekamp-Welch algorithm.
-func (fc *RSGFp) Correct(shares []Share) ([]Share, error) {
-	k := fc.k
-	r := len(shares)
-	if len(shares) < k {
-		return nil, errTooFewShards
-	}
-
-	// Sort the shares by their number
-	sort.Sort(byNumber(shares))
-
-	e := (r - k) / 2
-	// Use Berlekamp-Welch algorithm to correct errors
-	for i := 0; i <= e; i++ {
-		correctedShares, err := fc.BerlekampWelch(shares, i)
-		if err != nil {
+		seen[share.Number] = true
+		if share.missing() || erased[share.Number] {
 			continue
 		}
-		//fmt.Printf("correctedData: %v\n", correctedShares)
-		return correctedShares, nil
-	}
-	return nil, tooManyErrors
-}
-
-/*
-// Decode will take a list of shares and decode the original data.
-func (oec *RSGFp) Decode(shares []Share, output func(Share)) error {
-	k := oec.k
-
-	if len(shares) < k {
-		return errTooFewShards
-	}
-
-	// Correct any errors in the shares
-	correctedShares, err := oec.Correct(shares)
-	if err != nil {
-		return err
-	}
-
-	// Sort the shares by their number
-	sort.Sort(byNumber(correctedShares))
-
-	err = oec.Rebuild(correctedShares, output)
-	if err != nil {
-		return err
+		kept = append(kept, share)
 	}
-	return nil
+	return kept, nil
 }
-*/
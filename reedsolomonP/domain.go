@@ -0,0 +1,107 @@
+package reedsolomonP
+
+import (
+	"errors"
+	"fmt"
+
+	"oec/field"
+	"oec/utils"
+)
+
+type domainKind int
+
+const (
+	consecutiveDomain domainKind = iota
+	powersDomain
+	rootsOfUnityDomain
+	listDomain
+)
+
+// EvaluationDomain chooses the evaluation points of a code: share i is the
+// evaluation of the message polynomial at the i-th point of the domain.
+// Encode, Rebuild and all decoders read their points from it.
+type EvaluationDomain[E any] struct {
+	kind   domainKind
+	start  int64
+	gen    E
+	points []E
+}
+
+// ConsecutiveDomain evaluates share i at the field element encoded by the
+// integer start+i. The default domain of a code is ConsecutiveDomain(1).
+func ConsecutiveDomain[E any](start int64) EvaluationDomain[E] {
+	return EvaluationDomain[E]{kind: consecutiveDomain, start: start}
+}
+
+// PowersDomain evaluates share i at g^i. The order of g must be at least n.
+func PowersDomain[E any](g E) EvaluationDomain[E] {
+	return EvaluationDomain[E]{kind: powersDomain, gen: g}
+}
+
+// RootsOfUnityDomain evaluates share i at w^i for a primitive N-th root of
+// unity w, where N is the smallest power of two >= n if it divides q-1 and
+// N = n otherwise. N must divide q-1. With a power of two N, Encode and
+// Rebuild use the number-theoretic transform.
+func RootsOfUnityDomain[E any]() EvaluationDomain[E] {
+	return EvaluationDomain[E]{kind: rootsOfUnityDomain}
+}
+
+// ListDomain evaluates share i at points[i], for instance at the ID of the
+// party holding share i. The points must be distinct and there must be at
+// least n of them.
+func ListDomain[E any](points []E) EvaluationDomain[E] {
+	return EvaluationDomain[E]{kind: listDomain, points: append([]E{}, points...)}
+}
+
+// Points returns the first n points of the domain over f. It fails unless
+// they are distinct.
+func (d EvaluationDomain[E]) Points(f field.Field[E], n int) ([]E, error) {
+	xs := make([]E, n)
+	switch d.kind {
+	case consecutiveDomain:
+		for i := range xs {
+			xs[i] = f.FromInt64(d.start + int64(i))
+		}
+	case powersDomain, rootsOfUnityDomain:
+		g := d.gen
+		if d.kind == rootsOfUnityDomain {
+			w, err := utils.RootOfUnity(f, utils.NTTSize(n))
+			if err != nil {
+				if w, err = utils.RootOfUnity(f, n); err != nil {
+					return nil, err
+				}
+			}
+			g = w
+		}
+		x := f.One()
+		for i := range xs {
+			xs[i] = x
+			x = f.Mul(x, g)
+		}
+	case listDomain:
+		if len(d.points) < n {
+			return nil, fmt.Errorf("domain has %d points, %d needed", len(d.points), n)
+		}
+		copy(xs, d.points)
+	default:
+		return nil, errors.New("unknown evaluation domain")
+	}
+
+	seen := make(map[string]bool, n)
+	for _, x := range xs {
+		key := f.ToBig(x).String()
+		if seen[key] {
+			return nil, errors.New("evaluation points are not distinct")
+		}
+		seen[key] = true
+	}
+	return xs, nil
+}
+
+// WithDomain sets the evaluation points of the code. Its element type must
+// match the field of the code.
+func WithDomain[E any](d EvaluationDomain[E]) Option {
+	return func(o *options) {
+		o.domain = d
+	}
+}
@@ -0,0 +1,116 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"oec/field"
+	"oec/utils"
+)
+
+func TestEvaluationDomain(t *testing.T) {
+	p := big.NewInt(97)
+	f := field.NewPrime(p)
+	ids := []*big.Int{big.NewInt(0), big.NewInt(17), big.NewInt(5), big.NewInt(96), big.NewInt(42),
+		big.NewInt(3), big.NewInt(77), big.NewInt(60), big.NewInt(11), big.NewInt(1), big.NewInt(30), big.NewInt(8)}
+	domains := map[string]EvaluationDomain[*big.Int]{
+		"consecutive": ConsecutiveDomain[*big.Int](0),
+		"powers":      PowersDomain[*big.Int](big.NewInt(5)),
+		"roots":       RootsOfUnityDomain[*big.Int](),
+		"list":        ListDomain(ids),
+	}
+	decoders := []Decoder{BerlekampWelchDecoder, GaoDecoder, SyndromeDecoder, SugiyamaDecoder}
+	rnd := rand.New(rand.NewSource(5))
+	input := []*big.Int{big.NewInt(4), big.NewInt(90), big.NewInt(13), big.NewInt(7)}
+
+	for name, d := range domains {
+		for _, dec := range decoders {
+			fc, err := NewRSGFp(4, 12, p, WithDomain(d), WithDecoder(dec))
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			want, _ := fc.Encode(input)
+			poly := utils.NewPolyOf[*big.Int](f, input...)
+			for i, x := range fc.Points() {
+				if !f.Equal(poly.Eval(x), want[i].Data) {
+					t.Fatalf("%s: share %d is not the evaluation at %v", name, i, x)
+				}
+			}
+
+			shares, _ := fc.Encode(input)
+			for _, i := range rnd.Perm(12)[:4] {
+				shares[i].Data = f.Add(shares[i].Data, big.NewInt(rnd.Int63n(96)+1))
+			}
+			got, err := fc.Correct(shares)
+			if err != nil {
+				t.Fatalf("%s, decoder %d: %v", name, dec, err)
+			}
+			if !sharesEqual(got, want) {
+				t.Errorf("%s, decoder %d: expected %v, got %v", name, dec, want, got)
+			}
+
+			message := make([]*big.Int, 4)
+			fc.Rebuild(got[4:8], func(s Share) { message[s.Number] = s.Data })
+			for i := range input {
+				if message[i] == nil || message[i].Cmp(input[i]) != 0 {
+					t.Fatalf("%s: rebuilt %v, expected %v", name, message, input)
+				}
+			}
+		}
+
+		fc, _ := NewRSGFp(4, 12, p, WithDomain(d))
+		shares, _ := fc.Encode(input)
+		for i := 0; i < 5; i++ {
+			shares[2*i].Data = f.Add(shares[2*i].Data, f.One())
+		}
+		results, err := fc.ListDecode(shares)
+		if err != nil || len(results) == 0 || results[0].Corrected != 5 {
+			t.Errorf("%s: list decoding failed: %v", name, err)
+		}
+	}
+}
+
+func TestEvaluationDomain_Invalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		p   int64
+		opt Option
+	}{
+		"duplicate points": {97, WithDomain(ListDomain([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(98)}))},
+		"too few points":   {97, WithDomain(ListDomain([]*big.Int{big.NewInt(1), big.NewInt(2)}))},
+		"small order":      {97, WithDomain(PowersDomain[*big.Int](big.NewInt(96)))},
+		// neither 5 nor 8 divides 102
+		"no root of unity": {103, WithDomain(RootsOfUnityDomain[*big.Int]())},
+		"wrong field":      {97, WithDomain(ConsecutiveDomain[uint64](1))},
+	} {
+		if _, err := NewRSGFp(2, 5, big.NewInt(tc.p), tc.opt); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestEvaluationDomain_FullField(t *testing.T) {
+	// every element of GF(2^4) is an evaluation point
+	fc, err := NewRSGF2m(5, 16, 4, 0, WithDomain(ConsecutiveDomain[uint16](0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := []uint16{1, 2, 3, 4, 5}
+	want, _ := fc.Encode(input)
+	shares, _ := fc.Encode(input)
+	for i := 0; i < 5; i++ {
+		shares[3*i].Data ^= 9
+	}
+	got, err := fc.Correct(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if _, _, err := fc.SyndromeDecode(shares); err == nil {
+		t.Errorf("expected error for the Chien search over the whole field")
+	}
+}
@@ -0,0 +1,51 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"testing"
+
+	"oec/field"
+)
+
+func TestCorrectWithErasures(t *testing.T) {
+	p := big.NewInt(257)
+	input := []*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(4), big.NewInt(1)}
+
+	for _, d := range []Decoder{BerlekampWelchDecoder, GaoDecoder, SyndromeDecoder, SugiyamaDecoder} {
+		// n - k = 6 allows 4 erasures and 1 error
+		fc, _ := NewRSGFp(4, 10, p, WithDecoder(d))
+		want, _ := fc.Encode(input)
+
+		shares, _ := fc.Encode(input)
+		shares[1].Data = big.NewInt(0)
+		shares[3].Data = big.NewInt(0)
+		shares[5].Data = nil
+		shares[6].Data = big.NewInt(0)
+		shares[8].Data = field.NewPrime(p).Add(shares[8].Data, BigOne)
+
+		if _, err := fc.Correct(append([]Share{}, shares...)); err == nil {
+			t.Errorf("decoder %d: expected failure without erasure information", d)
+		}
+
+		got, err := fc.CorrectWithErasures(shares, []int{1, 3, 6})
+		if err != nil {
+			t.Fatalf("decoder %d: %v", d, err)
+		}
+		if !sharesEqual(got, want) {
+			t.Errorf("decoder %d: expected %v, got %v", d, want, got)
+		}
+	}
+}
+
+func TestCorrectWithErasures_TooManyErasures(t *testing.T) {
+	p := big.NewInt(257)
+	fc, _ := NewRSGFp(4, 6, p)
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(4), big.NewInt(1)})
+
+	if _, err := fc.CorrectWithErasures(shares, []int{0, 1, 2}); err != errTooFewShards {
+		t.Errorf("expected errTooFewShards, got %v", err)
+	}
+	if _, err := fc.CorrectWithErasures(shares, []int{6}); err == nil {
+		t.Errorf("expected error for invalid erasure position")
+	}
+}
@@ -25,3 +25,17 @@ var errNotSquare = errors.New("matrix is not square")
 var errSingular = errors.New("matrix is singular")
 
 var tooManyErrors = errors.New("too many errors to reconstruct")
+
+var errNoSolution = errors.New("linear system has no solution")
+
+// errInvalidBound is returned if the corruption bound does not fit the code parameters.
+var errInvalidBound = errors.New("invalid corruption bound")
+
+// errDuplicateShare is returned if a share with the same number was already received.
+var errDuplicateShare = errors.New("duplicate share number")
+
+// errNotDecoded is returned if the result of an online error correction is requested too early.
+var errNotDecoded = errors.New("shares have not been decoded yet")
+
+// errUnknownDecoder is returned if an unsupported decoder is requested.
+var errUnknownDecoder = errors.New("unknown decoder")
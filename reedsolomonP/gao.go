@@ -0,0 +1,56 @@
+package reedsolomonP
+
+import (
+	"oec/utils"
+)
+
+// GaoDecode corrects up to (len(shares)-k)/2 errors in the shares using Gao's
+// algorithm: interpolate the received word, run a partial extended Euclidean
+// algorithm against the product of (x - x_i), then divide.
+func (fc *RSGF[E]) GaoDecode(shares []ShareOf[E]) ([]ShareOf[E], error) {
+	pPoly, err := fc.gaoDecode(shares)
+	if err != nil {
+		return nil, err
+	}
+	return fc.evaluate(pPoly)
+}
+
+// gaoDecode returns the coefficients of the message polynomial P.
+func (fc *RSGF[E]) gaoDecode(shares []ShareOf[E]) ([]E, error) {
+	k := fc.k
+	m := len(shares)
+	xs, ys, err := fc.points(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	// g0 = (x - x_1)(x - x_2)...(x - x_m) and g1 interpolates the received word
+	var g0, g1 utils.PolyOf[E]
+	if m >= subproductThreshold {
+		tree := utils.NewSubproductTree(fc.f, xs)
+		g0 = tree.Root()
+		g1, err = tree.Interpolate(ys)
+	} else {
+		g0 = utils.FromRoots(fc.f, xs)
+		g1, err = utils.Interpolate(fc.f, xs, ys)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// partial extended Euclidean algorithm: stop at the first remainder
+	// g = u*g0 + v*g1 with deg g < (m+k)/2
+	g, _, v, err := g0.ExtendedGCD(g1, (m+k+1)/2)
+	if err != nil {
+		return nil, err
+	}
+
+	f, rem, err := g.DivMod(v)
+	if err != nil {
+		return nil, tooManyErrors
+	}
+	if !rem.IsZero() || f.Degree() >= k {
+		return nil, tooManyErrors
+	}
+	return f.Coefficients(k), nil
+}
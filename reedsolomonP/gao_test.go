@@ -0,0 +1,66 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"oec/field"
+)
+
+// corrupt adds a non-zero offset to errs distinct shares chosen at random.
+func corrupt(shares []Share, errs int, p *big.Int, rnd *rand.Rand) {
+	for _, i := range rnd.Perm(len(shares))[:errs] {
+		offset := big.NewInt(rnd.Int63n(p.Int64()-1) + 1)
+		shares[i].Data = field.NewPrime(p).Add(shares[i].Data, offset)
+	}
+}
+
+func TestGaoDecode(t *testing.T) {
+	p := big.NewInt(257)
+	rnd := rand.New(rand.NewSource(1))
+	for _, tc := range []struct{ k, n int }{{1, 3}, {3, 7}, {4, 13}, {8, 16}} {
+		fc, err := NewRSGFp(tc.k, tc.n, p, WithDecoder(GaoDecoder))
+		if err != nil {
+			t.Fatal(err)
+		}
+		input := make([]*big.Int, tc.k)
+		for i := range input {
+			input[i] = big.NewInt(rnd.Int63n(p.Int64()))
+		}
+		want, _ := fc.Encode(input)
+
+		for errs := 0; errs <= (tc.n-tc.k)/2; errs++ {
+			shares, _ := fc.Encode(input)
+			corrupt(shares, errs, p, rnd)
+			got, err := fc.GaoDecode(shares)
+			if err != nil {
+				t.Fatalf("k=%d n=%d errors=%d: %v", tc.k, tc.n, errs, err)
+			}
+			if !sharesEqual(got, want) {
+				t.Errorf("k=%d n=%d errors=%d: expected %v, got %v", tc.k, tc.n, errs, want, got)
+			}
+
+			got, err = fc.Correct(shares)
+			if err != nil || !sharesEqual(got, want) {
+				t.Errorf("Correct k=%d n=%d errors=%d: %v", tc.k, tc.n, errs, err)
+			}
+		}
+	}
+}
+
+func TestGaoDecode_TooManyErrors(t *testing.T) {
+	p := big.NewInt(257)
+	fc, _ := NewRSGFp(3, 7, p, WithDecoder(GaoDecoder))
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	corrupt(shares, 3, p, rand.New(rand.NewSource(2)))
+	if _, err := fc.Correct(shares); err == nil {
+		t.Errorf("expected decoding to fail with 3 errors")
+	}
+}
+
+func TestNewRSGFp_UnknownDecoder(t *testing.T) {
+	if _, err := NewRSGFp(3, 7, big.NewInt(257), WithDecoder(Decoder(42))); err != errUnknownDecoder {
+		t.Errorf("expected errUnknownDecoder, got %v", err)
+	}
+}
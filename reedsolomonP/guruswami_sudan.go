@@ -0,0 +1,349 @@
+package reedsolomonP
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"oec/field"
+	"oec/utils"
+)
+
+// bivariate is a polynomial Q(x, y) = sum_j q[j](x) y^j, where q[j] holds the
+// coefficients of q_j(x) in increasing order.
+type bivariate[E any] [][]E
+
+// ListDecode runs the Guruswami-Sudan list decoder. It returns every message
+// polynomial of degree < k that agrees with at least sqrt(k*m) of the m
+// received shares, which goes beyond the (m-k)/2 errors of unique decoding.
+// The candidates are sorted by the number of faulty shares. Shares with nil
+// Data are treated as erasures.
+//
+// The interpolation polynomial Q(x, y) passes through every received point
+// with multiplicity r and is found with Kötter's algorithm; the candidates are
+// the factors y - f(x) of Q, found by Roth-Ruckenstein root finding.
+func (fc *RSGF[E]) ListDecode(shares []ShareOf[E]) ([]*CorrectionResultOf[E], error) {
+	k := fc.k
+	f := fc.f
+	kept, err := fc.dropErasures(shares, nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(byNumber[E](kept))
+	xs, ys, err := fc.points(kept)
+	if err != nil {
+		return nil, err
+	}
+	m := len(kept)
+
+	// agreement threshold tau = ceil(sqrt(k*m))
+	tau := int(new(big.Int).Sqrt(big.NewInt(int64(k * m))).Int64())
+	if tau*tau < k*m {
+		tau++
+	}
+
+	var candidates [][]E
+	if k == 1 {
+		// constant messages: every value received at least tau times
+		count := make(map[string]int)
+		for _, y := range ys {
+			key := f.ToBig(y).String()
+			count[key]++
+			if count[key] == tau {
+				candidates = append(candidates, []E{y})
+			}
+		}
+	} else {
+		r, l := gsParameters(k, m, tau)
+		q := kotter(f, xs, ys, r, l, k-1)
+		candidates = rothRuckenstein(f, q, k)
+	}
+
+	var results []*CorrectionResultOf[E]
+	seen := make(map[string]bool)
+	for _, poly := range candidates {
+		key := polyKey(f, poly)
+		if seen[key] || fc.agreement(kept, poly) < tau {
+			continue
+		}
+		seen[key] = true
+		result, err := fc.newResult(kept, poly)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Corrected < results[j].Corrected
+	})
+	return results, nil
+}
+
+// gsParameters returns the smallest multiplicity r for which an interpolation
+// polynomial of (1, k-1)-weighted degree below tau*r exists, together with
+// the largest y-degree l such a polynomial can have.
+func gsParameters(k, m, tau int) (int, int) {
+	w := k - 1
+	for r := 1; ; r++ {
+		// number of linear constraints imposed by the multiplicities
+		constraints := m * r * (r + 1) / 2
+		// smallest weighted degree d with more monomials than constraints
+		d, monomials := 0, 0
+		for {
+			monomials = 0
+			for j := 0; j*w <= d; j++ {
+				monomials += d - j*w + 1
+			}
+			if monomials > constraints {
+				break
+			}
+			d++
+		}
+		if tau*r > d {
+			return r, d / w
+		}
+	}
+}
+
+// kotter returns a non-zero Q(x, y) with y-degree at most l that has a zero of
+// multiplicity r at every point (xs[i], ys[i]) and minimal (1, w)-weighted
+// degree.
+func kotter[E any](f field.Field[E], xs, ys []E, r, l, w int) bivariate[E] {
+	g := make([]bivariate[E], l+1)
+	for j := range g {
+		g[j] = make(bivariate[E], j+1)
+		for i := range g[j] {
+			g[j][i] = []E{f.Zero()}
+		}
+		g[j][j][0] = f.One()
+	}
+	// every constraint raises the x-degree of at most one polynomial by one
+	binom := binomials(f, len(xs)*r*(r+1)/2+l+1, r)
+
+	for i := range xs {
+		// process (a, b) so that (a-1, b) always comes before (a, b)
+		for b := 0; b < r; b++ {
+			for a := 0; a < r-b; a++ {
+				delta := make([]E, len(g))
+				best := -1
+				for j := range g {
+					delta[j] = hasse(f, g[j], a, b, xs[i], ys[i], binom)
+					if f.IsZero(delta[j]) {
+						continue
+					}
+					if best < 0 || weightedDegree(f, g[j], w) < weightedDegree(f, g[best], w) {
+						best = j
+					}
+				}
+				if best < 0 {
+					continue
+				}
+				for j := range g {
+					if j != best && !f.IsZero(delta[j]) {
+						g[j] = combine(f, g[j], delta[best], g[best], delta[j])
+					}
+				}
+				g[best] = mulLinear(f, g[best], xs[i])
+			}
+		}
+	}
+
+	best := 0
+	for j := range g {
+		if weightedDegree(f, g[j], w) < weightedDegree(f, g[best], w) {
+			best = j
+		}
+	}
+	return g[best]
+}
+
+// binomials returns the binomial coefficients C(i, j) in the field for
+// i < rows and j < min(i+1, cols).
+func binomials[E any](f field.Field[E], rows, cols int) [][]E {
+	c := make([][]E, rows)
+	for i := range c {
+		c[i] = make([]E, min(i+1, cols))
+		c[i][0] = f.One()
+		for j := 1; j < len(c[i]); j++ {
+			if j == i {
+				c[i][j] = f.One()
+			} else {
+				c[i][j] = f.Add(c[i-1][j-1], c[i-1][j])
+			}
+		}
+	}
+	return c
+}
+
+// hasse evaluates the (a, b)-th Hasse derivative of q at (x0, y0):
+// sum_{i>=a, j>=b} C(i, a) C(j, b) q_ij x0^(i-a) y0^(j-b).
+func hasse[E any](f field.Field[E], q bivariate[E], a, b int, x0, y0 E, binom [][]E) E {
+	result := f.Zero()
+	yPow := f.One()
+	for j := b; j < len(q); j++ {
+		inner := f.Zero()
+		xPow := f.One()
+		for i := a; i < len(q[j]); i++ {
+			inner = f.Add(inner, f.Mul(binom[i][a], f.Mul(q[j][i], xPow)))
+			xPow = f.Mul(xPow, x0)
+		}
+		result = f.Add(result, f.Mul(f.Mul(binom[j][b], inner), yPow))
+		yPow = f.Mul(yPow, y0)
+	}
+	return result
+}
+
+// weightedDegree returns the (1, w)-weighted degree of q, or -1 for q = 0.
+func weightedDegree[E any](f field.Field[E], q bivariate[E], w int) int {
+	deg := -1
+	for j := range q {
+		for i := len(q[j]) - 1; i >= 0; i-- {
+			if !f.IsZero(q[j][i]) {
+				deg = max(deg, i+w*j)
+				break
+			}
+		}
+	}
+	return deg
+}
+
+// combine returns s*u - t*v.
+func combine[E any](f field.Field[E], u bivariate[E], s E, v bivariate[E], t E) bivariate[E] {
+	out := make(bivariate[E], max(len(u), len(v)))
+	for j := range out {
+		var uj, vj []E
+		if j < len(u) {
+			uj = u[j]
+		}
+		if j < len(v) {
+			vj = v[j]
+		}
+		out[j] = make([]E, max(len(uj), len(vj), 1))
+		for i := range out[j] {
+			out[j][i] = f.Zero()
+			if i < len(uj) {
+				out[j][i] = f.Mul(s, uj[i])
+			}
+			if i < len(vj) {
+				out[j][i] = f.Sub(out[j][i], f.Mul(t, vj[i]))
+			}
+		}
+	}
+	return out
+}
+
+// mulLinear returns (x - x0) * q.
+func mulLinear[E any](f field.Field[E], q bivariate[E], x0 E) bivariate[E] {
+	out := make(bivariate[E], len(q))
+	for j := range q {
+		out[j] = make([]E, len(q[j])+1)
+		for i := range out[j] {
+			out[j][i] = f.Zero()
+			if i > 0 {
+				out[j][i] = q[j][i-1]
+			}
+			if i < len(q[j]) {
+				out[j][i] = f.Sub(out[j][i], f.Mul(x0, q[j][i]))
+			}
+		}
+	}
+	return out
+}
+
+// rothRuckenstein returns the candidates f of degree < k for which y - f(x)
+// may divide q, found coefficient by coefficient.
+func rothRuckenstein[E any](f field.Field[E], q bivariate[E], k int) [][]E {
+	var out [][]E
+	var search func(q bivariate[E], prefix []E)
+	search = func(q bivariate[E], prefix []E) {
+		q = divideX(f, q)
+		if q == nil {
+			return
+		}
+		// the next coefficient of f is a root of Q(0, y)
+		m := make([]E, len(q))
+		for j := range q {
+			m[j] = q[j][0]
+		}
+		roots, err := utils.NewPolyOf(f, m...).Roots()
+		if err != nil {
+			return
+		}
+		for _, gamma := range roots {
+			next := append(append([]E{}, prefix...), gamma)
+			if len(next) == k {
+				out = append(out, next)
+				continue
+			}
+			search(substitute(f, q, gamma), next)
+		}
+	}
+	search(q, nil)
+	return out
+}
+
+// divideX divides q by the largest power of x dividing it. It returns nil
+// for q = 0.
+func divideX[E any](f field.Field[E], q bivariate[E]) bivariate[E] {
+	shift := -1
+	for j := range q {
+		for i := range q[j] {
+			if !f.IsZero(q[j][i]) {
+				if shift < 0 || i < shift {
+					shift = i
+				}
+				break
+			}
+		}
+	}
+	if shift < 0 {
+		return nil
+	}
+	out := make(bivariate[E], len(q))
+	for j := range q {
+		if shift < len(q[j]) {
+			out[j] = q[j][shift:]
+		} else {
+			out[j] = []E{f.Zero()}
+		}
+	}
+	return out
+}
+
+// substitute returns Q(x, x*y + gamma), whose y^l coefficient is
+// x^l * sum_{j>=l} C(j, l) gamma^(j-l) q_j(x).
+func substitute[E any](f field.Field[E], q bivariate[E], gamma E) bivariate[E] {
+	binom := binomials(f, len(q), len(q))
+	out := make(bivariate[E], len(q))
+	for l := range q {
+		var sum []E
+		gPow := f.One()
+		for j := l; j < len(q); j++ {
+			c := f.Mul(binom[j][l], gPow)
+			for len(sum) < len(q[j]) {
+				sum = append(sum, f.Zero())
+			}
+			for i := range q[j] {
+				sum[i] = f.Add(sum[i], f.Mul(c, q[j][i]))
+			}
+			gPow = f.Mul(gPow, gamma)
+		}
+		out[l] = make([]E, l+len(sum))
+		for i := range out[l] {
+			out[l][i] = f.Zero()
+			if i >= l {
+				out[l][i] = sum[i-l]
+			}
+		}
+	}
+	return out
+}
+
+// polyKey returns a string identifying the coefficients poly.
+func polyKey[E any](f field.Field[E], poly []E) string {
+	key := make([]*big.Int, len(poly))
+	for i, c := range poly {
+		key[i] = f.ToBig(c)
+	}
+	return fmt.Sprint(key)
+}
@@ -0,0 +1,75 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestListDecode_BeyondHalfDistance(t *testing.T) {
+	p := big.NewInt(1009)
+	rnd := rand.New(rand.NewSource(5))
+	fc, _ := NewRSGFp(3, 15, p)
+	input := []*big.Int{big.NewInt(17), big.NewInt(4), big.NewInt(600)}
+	want, _ := fc.Encode(input)
+
+	// unique decoding corrects 6 errors, sqrt(3*15) < 7 agreements allow 8
+	shares, _ := fc.Encode(input)
+	corrupt(shares, 8, p, rnd)
+
+	results, err := fc.ListDecode(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, result := range results {
+		if sharesEqual(result.Codeword, want) {
+			found = true
+			if result.Corrected != 8 {
+				t.Errorf("expected 8 faulty shares, got %v", result.Faulty)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected %v among the candidates %v", want, results)
+	}
+}
+
+func TestListDecode_TwoCandidates(t *testing.T) {
+	p := big.NewInt(1009)
+	fc, _ := NewRSGFp(3, 15, p)
+	a, _ := fc.Encode([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	b, _ := fc.Encode([]*big.Int{big.NewInt(500), big.NewInt(7), big.NewInt(42)})
+
+	// the received word agrees with a on 8 and with b on 7 shares
+	shares := append(append([]Share{}, a[:8]...), b[8:]...)
+	results, err := fc.ListDecode(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 candidates, got %d", len(results))
+	}
+	if !sharesEqual(results[0].Codeword, a) || !sharesEqual(results[1].Codeword, b) {
+		t.Errorf("expected candidates %v and %v, got %v and %v", a, b, results[0].Codeword, results[1].Codeword)
+	}
+}
+
+func TestListDecode_Constant(t *testing.T) {
+	p := big.NewInt(101)
+	fc, _ := NewRSGFp(1, 9, p)
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(5)})
+	for i := 0; i < 4; i++ {
+		shares[i].Data = big.NewInt(9)
+	}
+	results, err := fc.ListDecode(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 candidates, got %d", len(results))
+	}
+	if results[0].Message[0].Int64() != 5 || results[1].Message[0].Int64() != 9 {
+		t.Errorf("expected candidates 5 and 9, got %v and %v", results[0].Message, results[1].Message)
+	}
+}
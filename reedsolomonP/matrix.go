@@ -0,0 +1,273 @@
+package reedsolomonP
+
+import (
+	"errors"
+	"fmt"
+
+	"oec/field"
+)
+
+// MatrixOf is a matrix over a field with elements of type E. P is the same
+// matrix over GF(p) with *big.Int elements.
+type MatrixOf[E any] [][]E
+
+// newMatrix returns a matrix of zeros.
+func newMatrix[E any](f field.Field[E], rows, cols int) (MatrixOf[E], error) {
+	if rows <= 0 {
+		return nil, errInvalidRowSize
+	}
+	if cols <= 0 {
+		return nil, errInvalidColSize
+	}
+
+	m := make(MatrixOf[E], rows)
+	for i := range m {
+		m[i] = make([]E, cols)
+		for j := range m[i] {
+			m[i][j] = f.Zero()
+		}
+	}
+	return m, nil
+}
+
+// identityMatrix returns an identity matrix of the given size.
+func identityMatrix[E any](f field.Field[E], size int) (MatrixOf[E], error) {
+	m, err := newMatrix(f, size, size)
+	if err != nil {
+		return nil, err
+	}
+	for i := range m {
+		m[i][i] = f.One()
+	}
+	return m, nil
+}
+
+// Vandermonde returns the matrix with rows (1, x, x^2, ..., x^(cols-1)) for
+// every x in xs. Any square subset of its rows is invertible when the xs are
+// distinct.
+func Vandermonde[E any](f field.Field[E], xs []E, cols int) (MatrixOf[E], error) {
+	result, err := newMatrix(f, len(xs), cols)
+	if err != nil {
+		return nil, err
+	}
+	for r, row := range result {
+		v := f.One()
+		for c := range row {
+			row[c] = v
+			v = f.Mul(v, xs[r])
+		}
+	}
+	return result, nil
+}
+
+// HyperInvertible returns the matrix of Beerliová-Hirt that maps the values
+// at inputs of a polynomial of degree < len(inputs) to its values at outputs,
+// V(outputs) * V(inputs)^-1 for the Vandermonde matrices V with len(inputs)
+// columns. If the inputs and outputs are distinct, every square submatrix is
+// invertible: any m of the 2n values at inputs and outputs determine the
+// other ones linearly.
+func HyperInvertible[E any](f field.Field[E], inputs, outputs []E) (MatrixOf[E], error) {
+	seen := make(map[string]bool, len(inputs)+len(outputs))
+	for _, x := range append(append([]E{}, inputs...), outputs...) {
+		key := f.ToBig(x).String()
+		if seen[key] {
+			return nil, errors.New("evaluation points are not distinct")
+		}
+		seen[key] = true
+	}
+	in, err := Vandermonde(f, inputs, len(inputs))
+	if err != nil {
+		return nil, err
+	}
+	inv, err := in.Invert(f)
+	if err != nil {
+		return nil, err
+	}
+	out, err := Vandermonde(f, outputs, len(inputs))
+	if err != nil {
+		return nil, err
+	}
+	return out.Multiply(f, inv)
+}
+
+// Multiply multiplies this matrix (the one on the left) by another
+// matrix (the one on the right) and returns a new matrix with the result.
+func (m MatrixOf[E]) Multiply(f field.Field[E], right MatrixOf[E]) (MatrixOf[E], error) {
+	if len(m[0]) != len(right) {
+		return nil, fmt.Errorf("columns on left (%d) is different than rows on right (%d)", len(m[0]), len(right))
+	}
+	result, _ := newMatrix(f, len(m), len(right[0]))
+	for r, row := range result {
+		for c := range row {
+			value := f.Zero()
+			for i := range m[0] {
+				value = f.Add(value, f.Mul(m[r][i], right[i][c]))
+			}
+			result[r][c] = value
+		}
+	}
+	return result, nil
+}
+
+// Augment returns the concatenation of this matrix and the matrix on the right.
+func (m MatrixOf[E]) Augment(right MatrixOf[E]) (MatrixOf[E], error) {
+	if len(m) != len(right) {
+		return nil, errMatrixSize
+	}
+
+	result := make(MatrixOf[E], len(m))
+	for r := range m {
+		result[r] = append(append(make([]E, 0, len(m[r])+len(right[r])), m[r]...), right[r]...)
+	}
+	return result, nil
+}
+
+// SubMatrix returns a part of this matrix. Data is copied.
+func (m MatrixOf[E]) SubMatrix(rmin, cmin, rmax, cmax int) (MatrixOf[E], error) {
+	if rmax <= rmin {
+		return nil, errInvalidRowSize
+	}
+	if cmax <= cmin {
+		return nil, errInvalidColSize
+	}
+	result := make(MatrixOf[E], rmax-rmin)
+	for r := rmin; r < rmax; r++ {
+		result[r-rmin] = append([]E{}, m[r][cmin:cmax]...)
+	}
+	return result, nil
+}
+
+// IsSquare will return true if the matrix is square, otherwise false.
+func (m MatrixOf[E]) IsSquare() bool {
+	return len(m) == len(m[0])
+}
+
+// Invert returns the inverse of this matrix.
+// Returns ErrSingular when the matrix is singular and doesn't have an inverse.
+// The matrix must be square, otherwise ErrNotSquare is returned.
+func (m MatrixOf[E]) Invert(f field.Field[E]) (MatrixOf[E], error) {
+	if !m.IsSquare() {
+		return nil, errNotSquare
+	}
+
+	size := len(m)
+	work, _ := identityMatrix(f, size)
+
+	work, _ = m.Augment(work)
+
+	err := work.gaussianElimination(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return work.SubMatrix(0, size, size, size*2)
+}
+
+// gaussianElimination reduces the left half of the n x 2n matrix to the identity.
+func (m MatrixOf[E]) gaussianElimination(f field.Field[E]) error {
+	n := len(m)
+	for i := 0; i < n; i++ {
+		// Find the pivot row
+		pivotRow := -1
+		for j := i; j < n; j++ {
+			if !f.IsZero(m[j][i]) {
+				pivotRow = j
+				break
+			}
+		}
+		if pivotRow < 0 {
+			return errSingular
+		}
+
+		// Swap the current row with the pivot row
+		m[i], m[pivotRow] = m[pivotRow], m[i]
+
+		// Make the pivot element 1
+		pivotInv, err := f.Inv(m[i][i])
+		if err != nil {
+			return err
+		}
+		for j := i; j < 2*n; j++ {
+			m[i][j] = f.Mul(m[i][j], pivotInv)
+		}
+
+		// Eliminate the other rows
+		for k := 0; k < n; k++ {
+			if k != i {
+				factor := m[k][i]
+				for j := i; j < 2*n; j++ {
+					m[k][j] = f.Sub(m[k][j], f.Mul(m[i][j], factor))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Solve returns a solution u of m * u = b. The system may be over- or
+// under-determined; free variables are set to zero.
+// Returns errNoSolution when the system is inconsistent.
+func (m MatrixOf[E]) Solve(f field.Field[E], b []E) ([]E, error) {
+	if len(m) != len(b) {
+		return nil, errMatrixSize
+	}
+	rows := len(m)
+	cols := len(m[0])
+
+	// work on a copy of the augmented matrix [m | b]
+	work := make(MatrixOf[E], rows)
+	for r := range m {
+		work[r] = append(append(make([]E, 0, cols+1), m[r]...), b[r])
+	}
+
+	pivotCols := make([]int, 0, cols)
+	row := 0
+	for c := 0; c < cols && row < rows; c++ {
+		pivotRow := -1
+		for r := row; r < rows; r++ {
+			if !f.IsZero(work[r][c]) {
+				pivotRow = r
+				break
+			}
+		}
+		if pivotRow < 0 {
+			continue
+		}
+		work[row], work[pivotRow] = work[pivotRow], work[row]
+
+		pivotInv, err := f.Inv(work[row][c])
+		if err != nil {
+			return nil, err
+		}
+		for j := c; j <= cols; j++ {
+			work[row][j] = f.Mul(work[row][j], pivotInv)
+		}
+		for r := 0; r < rows; r++ {
+			if r == row || f.IsZero(work[r][c]) {
+				continue
+			}
+			factor := work[r][c]
+			for j := c; j <= cols; j++ {
+				work[r][j] = f.Sub(work[r][j], f.Mul(work[row][j], factor))
+			}
+		}
+		pivotCols = append(pivotCols, c)
+		row++
+	}
+
+	// any remaining row reads 0 = b_r
+	for r := row; r < rows; r++ {
+		if !f.IsZero(work[r][cols]) {
+			return nil, errNoSolution
+		}
+	}
+
+	u := make([]E, cols)
+	for c := range u {
+		u[c] = f.Zero()
+	}
+	for r, c := range pivotCols {
+		u[c] = work[r][cols]
+	}
+	return u, nil
+}
@@ -1,11 +1,13 @@
 package reedsolomonP
 
 import (
-	"fmt"
 	"math/big"
 	"strings"
+
+	"oec/field"
 )
 
+// P is a matrix over GF(p). Its arithmetic is that of MatrixOf over field.Prime.
 type P [][]*big.Int
 
 var BigZero = big.NewInt(0)
@@ -90,21 +92,8 @@ func identityMatrixP(size int) (P, error) {
 // Multiply multiplies this matrix (the one on the left) by another
 // matrix (the one on the right) and returns a new matrix with the result.
 func (m P) Multiply(right P, p *big.Int) (P, error) {
-	if len(m[0]) != len(right) {
-		return nil, fmt.Errorf("columns on left (%d) is different than rows on right (%d)", len(m[0]), len(right))
-	}
-	result, _ := newMatrixP(len(m), len(right[0]))
-	for r, row := range result {
-		for c := range row {
-			value := new(big.Int).SetInt64(0)
-			for i := range m[0] {
-				tmp := new(big.Int).Mul(m[r][i], right[i][c])
-				value = new(big.Int).Add(value, tmp)
-			}
-			result[r][c] = new(big.Int).Mod(value, p)
-		}
-	}
-	return result, nil
+	result, err := MatrixOf[*big.Int](m).Multiply(field.NewPrime(p), MatrixOf[*big.Int](right))
+	return P(result), err
 }
 
 // Augment returns the concatenation of this matrix and the matrix on the right.
@@ -171,76 +160,47 @@ func (m P) IsSquare() bool {
 // Returns ErrSingular when the matrix is singular and doesn't have an inverse.
 // The matrix must be square, otherwise ErrNotSquare is returned.
 func (m P) Invert(p *big.Int) (P, error) {
-	if !m.IsSquare() {
-		return nil, errNotSquare
-	}
-
-	size := len(m)
-	work, _ := identityMatrixP(size)
-
-	work, _ = m.Augment(work)
-
-	err := work.gaussianElimination(p)
-	if err != nil {
-		return nil, err
-	}
-
-	return work.SubMatrix(0, size, size, size*2)
-}
-
-// gaussianElimination performs Gaussian elimination on the matrix.
-func (m P) gaussianElimination(p *big.Int) error {
-	n := len(m)
-	for i := 0; i < n; i++ {
-		// Find the pivot row
-		pivotRow := i
-		for j := i + 1; j < n; j++ {
-			if (m)[j][i].Cmp(big.NewInt(0)) != 0 {
-				pivotRow = j
-				break
-			}
-		}
-
-		if (m)[pivotRow][i].Cmp(big.NewInt(0)) == 0 {
-			return errSingular
-		}
-
-		// Swap the current row with the pivot row
-		(m)[i], (m)[pivotRow] = (m)[pivotRow], (m)[i]
-
-		// Make the pivot element 1
-		pivotInv, err := modInverse((m)[i][i], p)
-		if err != nil {
-			return err
-		}
-		for j := i; j < 2*n; j++ {
-			(m)[i][j] = modMul((m)[i][j], pivotInv, p)
-		}
-
-		// Eliminate the other rows
-		for k := 0; k < n; k++ {
-			if k != i {
-				factor := (m)[k][i]
-				for j := i; j < 2*n; j++ {
-					(m)[k][j] = modSub((m)[k][j], modMul((m)[i][j], factor, p), p)
-				}
-			}
-		}
-	}
-	return nil
+	result, err := MatrixOf[*big.Int](m).Invert(field.NewPrime(p))
+	return P(result), err
 }
 
 // VandermondeP creates a Vandermonde matrix, which is guaranteed to have the
 // property that any subset of rows that forms a square matrix is invertible.
 func VandermondeP(rows, cols int, p *big.Int) (P, error) {
-	result, err := newMatrixP(rows, cols)
-	if err != nil {
-		return nil, err
+	if rows <= 0 {
+		return nil, errInvalidRowSize
 	}
-	for r, row := range result {
-		for c := range row {
-			result[r][c] = new(big.Int).Exp(new(big.Int).SetInt64(int64(r+1)), new(big.Int).SetInt64(int64(c)), p)
-		}
+	f := field.NewPrime(p)
+	xs := make([]*big.Int, rows)
+	for r := range xs {
+		xs[r] = f.FromInt64(int64(r + 1))
 	}
-	return result, nil
+	result, err := Vandermonde[*big.Int](f, xs, cols)
+	return P(result), err
+}
+
+// HyperInvertibleP returns the rows x cols hyper-invertible matrix over
+// GF(p) with inputs 1, ..., cols and outputs cols+1, ..., cols+rows, which
+// are distinct for p > rows+cols.
+func HyperInvertibleP(rows, cols int, p *big.Int) (P, error) {
+	if rows <= 0 {
+		return nil, errInvalidRowSize
+	}
+	if cols <= 0 {
+		return nil, errInvalidColSize
+	}
+	f := field.NewPrime(p)
+	xs := make([]*big.Int, cols+rows)
+	for i := range xs {
+		xs[i] = f.FromInt64(int64(i + 1))
+	}
+	result, err := HyperInvertible[*big.Int](f, xs[:cols], xs[cols:])
+	return P(result), err
+}
+
+// Solve returns a solution u of m * u = b over GF(p). The system may be
+// over- or under-determined; free variables are set to zero.
+// Returns errNoSolution when the system is inconsistent.
+func (m P) Solve(b []*big.Int, p *big.Int) ([]*big.Int, error) {
+	return MatrixOf[*big.Int](m).Solve(field.NewPrime(p), b)
 }
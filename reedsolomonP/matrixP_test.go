@@ -2,7 +2,11 @@ package reedsolomonP
 
 import (
 	"math/big"
+	"math/bits"
 	"testing"
+
+	"oec/field"
+	"oec/utils"
 )
 
 // TestInvert_IdentityMatrix tests the inversion of an identity matrix.
@@ -88,3 +92,54 @@ func TestInvert_LargeMatrix(t *testing.T) {
 		}
 	}
 }
+
+// TestHyperInvertibleP checks that every square submatrix of a
+// hyper-invertible matrix is invertible.
+func TestHyperInvertibleP(t *testing.T) {
+	p := big.NewInt(29)
+	m, err := HyperInvertibleP(4, 4, p)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	// the matrix maps the values at 1..4 of a cubic to its values at 5..8
+	f := field.NewPrime(p)
+	poly := utils.NewPolyOf[*big.Int](f, f.FromInt64(3), f.FromInt64(1), f.FromInt64(4), f.FromInt64(1))
+	in := make(P, 4)
+	for i := range in {
+		in[i] = []*big.Int{poly.Eval(f.FromInt64(int64(i + 1)))}
+	}
+	out, _ := m.Multiply(in, p)
+	for i := range out {
+		if want := poly.Eval(f.FromInt64(int64(i + 5))); out[i][0].Cmp(want) != 0 {
+			t.Errorf("Expected value %v at %d, got: %v", want, i+5, out[i][0])
+		}
+	}
+
+	for rows := 1; rows < 1<<4; rows++ {
+		for cols := 1; cols < 1<<4; cols++ {
+			if bits.OnesCount(uint(rows)) != bits.OnesCount(uint(cols)) {
+				continue
+			}
+			var sub P
+			for r := range m {
+				if rows>>r&1 == 0 {
+					continue
+				}
+				var row []*big.Int
+				for c := range m[r] {
+					if cols>>c&1 == 1 {
+						row = append(row, m[r][c])
+					}
+				}
+				sub = append(sub, row)
+			}
+			if _, err := sub.Invert(p); err != nil {
+				t.Errorf("Expected rows %b and columns %b to be invertible, got: %v", rows, cols, err)
+			}
+		}
+	}
+
+	if _, err := HyperInvertibleP(4, 4, big.NewInt(7)); err == nil {
+		t.Errorf("Expected error for points that are not distinct mod 7, got no error")
+	}
+}
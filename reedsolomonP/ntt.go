@@ -0,0 +1,113 @@
+package reedsolomonP
+
+import (
+	"oec/field"
+	"oec/utils"
+)
+
+// nttPlan describes evaluation points w^0, ..., w^(n-1) for a primitive N-th
+// root of unity w with N a power of two, which lets Encode and Rebuild use
+// the number-theoretic transform of size N.
+type nttPlan[E any] struct {
+	size int
+	root E
+}
+
+// newNTTPlan returns the plan for the points xs, or nil if they are not the
+// first powers of a root of unity of order utils.NTTSize(len(xs)).
+func newNTTPlan[E any](f field.Field[E], xs []E) *nttPlan[E] {
+	size := utils.NTTSize(len(xs))
+	if len(xs) < 2 || !f.Equal(xs[0], f.One()) {
+		return nil
+	}
+	w := xs[1]
+	for i := 2; i < len(xs); i++ {
+		if !f.Equal(xs[i], f.Mul(xs[i-1], w)) {
+			return nil
+		}
+	}
+	if !f.Equal(field.ExpInt(f, w, size), f.One()) || f.Equal(field.ExpInt(f, w, size/2), f.One()) {
+		return nil
+	}
+	// the inverse transform divides by N
+	if f.IsZero(field.MulInt(f, f.One(), size)) {
+		return nil
+	}
+	return &nttPlan[E]{size: size, root: w}
+}
+
+// encode evaluates the message at the n points with a single transform.
+func (t *nttPlan[E]) encode(f field.Field[E], input []E, n int) ([]E, error) {
+	a := make([]E, t.size)
+	copy(a, input)
+	for i := len(input); i < t.size; i++ {
+		a[i] = f.Zero()
+	}
+	values, err := utils.NTT(f, a, t.root)
+	if err != nil {
+		return nil, err
+	}
+	return values[:n], nil
+}
+
+// interpolate returns the k message coefficients from the values ys at the
+// points w^numbers[i], k = len(numbers). With Z(x) the product of (x - w^j)
+// over the N-k unused powers, (P*Z)(w^j) is ys[i]*Z(w^i) at the used points
+// and 0 elsewhere. P*Z has degree < N, so an inverse transform recovers it
+// and P follows by dividing by Z.
+func (t *nttPlan[E]) interpolate(f field.Field[E], numbers []int, ys []E) ([]E, error) {
+	k := len(numbers)
+	powers := make([]E, t.size)
+	powers[0] = f.One()
+	for i := 1; i < t.size; i++ {
+		powers[i] = f.Mul(powers[i-1], t.root)
+	}
+	used := make([]bool, t.size)
+	for _, number := range numbers {
+		used[number] = true
+	}
+
+	var z utils.PolyOf[E]
+	if 2*k < t.size {
+		// Z = (x^N - 1) / prod over the used points
+		var roots []E
+		for _, number := range numbers {
+			roots = append(roots, powers[number])
+		}
+		xN := utils.MonomialOf(f, f.One(), t.size).Sub(utils.NewPolyOf(f, f.One()))
+		q, _, err := xN.DivMod(utils.FromRoots(f, roots))
+		if err != nil {
+			return nil, err
+		}
+		z = q
+	} else {
+		var roots []E
+		for j, u := range used {
+			if !u {
+				roots = append(roots, powers[j])
+			}
+		}
+		z = utils.FromRoots(f, roots)
+	}
+
+	zValues, err := utils.NTT(f, z.Coefficients(t.size), t.root)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]E, t.size)
+	for j := range values {
+		values[j] = f.Zero()
+	}
+	for i, number := range numbers {
+		values[number] = f.Mul(ys[i], zValues[number])
+	}
+	pz, err := utils.InverseNTT(f, values, t.root)
+	if err != nil {
+		return nil, err
+	}
+	p, _, err := utils.NewPolyOf(f, pz...).DivMod(z)
+	if err != nil {
+		return nil, err
+	}
+	return p.Coefficients(k), nil
+}
@@ -0,0 +1,86 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"oec/utils"
+)
+
+func TestNTTFastPath(t *testing.T) {
+	rnd := rand.New(rand.NewSource(12))
+	for _, tc := range []struct{ k, n int }{{1, 2}, {3, 8}, {5, 12}, {100, 1000}, {700, 1024}} {
+		fc, err := NewRSGF64(tc.k, tc.n, 0xffffffff00000001, WithDomain(RootsOfUnityDomain[uint64]()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fc.ntt == nil || fc.ntt.size != utils.NTTSize(tc.n) {
+			t.Fatalf("k=%d n=%d: NTT fast path not selected", tc.k, tc.n)
+		}
+		if fc.encMatrix != nil {
+			t.Fatalf("k=%d n=%d: encoding matrix built for the NTT path", tc.k, tc.n)
+		}
+		f := fc.Field()
+		input := make([]uint64, tc.k)
+		for i := range input {
+			input[i] = f.FromInt64(rnd.Int63())
+		}
+		shares, err := fc.Encode(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		poly := utils.NewPolyOf[uint64](f, input...)
+		for _, i := range rnd.Perm(tc.n)[:min(tc.n, 20)] {
+			if poly.Eval(fc.xs[i]) != shares[i].Data {
+				t.Fatalf("k=%d n=%d: share %d is not the evaluation at w^%d", tc.k, tc.n, i, i)
+			}
+		}
+
+		// rebuild from k random shares
+		var subset []ShareOf[uint64]
+		for _, i := range rnd.Perm(tc.n)[:tc.k] {
+			subset = append(subset, shares[i])
+		}
+		message := make([]uint64, tc.k)
+		if err := fc.Rebuild(subset, func(s ShareOf[uint64]) { message[s.Number] = s.Data }); err != nil {
+			t.Fatal(err)
+		}
+		for i := range input {
+			if message[i] != input[i] {
+				t.Fatalf("k=%d n=%d: rebuilt message differs at %d", tc.k, tc.n, i)
+			}
+		}
+	}
+
+	// 3 does not divide p-1, so there is no transform of size 4 for n = 3
+	fc, _ := NewRSGFp(2, 3, big.NewInt(7), WithDomain(RootsOfUnityDomain[*big.Int]()))
+	if fc.ntt != nil {
+		t.Errorf("unexpected NTT fast path")
+	}
+}
+
+func BenchmarkRebuild(b *testing.B) {
+	p := uint64(0xffffffff00000001)
+	for _, tc := range []struct {
+		name   string
+		domain EvaluationDomain[uint64]
+	}{
+		{"SubproductTree", ConsecutiveDomain[uint64](1)},
+		{"NTT", RootsOfUnityDomain[uint64]()},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			fc, _ := NewRSGF64(256, 1024, p, WithDomain(tc.domain))
+			f := fc.Field()
+			input := make([]uint64, 256)
+			for i := range input {
+				input[i] = f.FromInt64(int64(i))
+			}
+			shares, _ := fc.Encode(input)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fc.Rebuild(shares[256:512], nil)
+			}
+		})
+	}
+}
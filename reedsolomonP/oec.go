@@ -0,0 +1,104 @@
+package reedsolomonP
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// OECSessionOf runs online error correction on shares that arrive one at a time,
+// as used in asynchronous VSS and ACS protocols.
+//
+// With corruption bound t, the session waits for k+t+r shares before it tries
+// to decode with r errors, for r = 0, 1, ..., t. A decoded polynomial is only
+// accepted once k+t received shares agree with it, so at least k of them come
+// from honest parties. For the usual k = t+1 these are the classic 2t+1+r and
+// 2t+1 thresholds.
+type OECSessionOf[E any] struct {
+	fc     *RSGF[E]
+	t      int
+	r      int
+	shares []ShareOf[E]
+	seen   map[int]bool
+	result *CorrectionResultOf[E]
+}
+
+// OECSession runs online error correction on shares over GF(p).
+type OECSession = OECSessionOf[*big.Int]
+
+// NewOECSession starts an online error correction session that tolerates up
+// to t corrupted shares. It requires k+2t <= n.
+func (fc *RSGF[E]) NewOECSession(t int) (*OECSessionOf[E], error) {
+	if t < 0 || fc.k+2*t > fc.n {
+		return nil, errInvalidBound
+	}
+	return &OECSessionOf[E]{
+		fc:   fc,
+		t:    t,
+		seen: make(map[int]bool),
+	}, nil
+}
+
+// Add feeds one share into the session and reports whether the shares have
+// been decoded. Once decoding succeeded further shares are ignored.
+// tooManyErrors is returned once more than t of the received shares are wrong.
+func (s *OECSessionOf[E]) Add(share ShareOf[E]) (bool, error) {
+	if s.result != nil {
+		return true, nil
+	}
+	if s.r > s.t {
+		return false, tooManyErrors
+	}
+	if share.Number < 0 || share.Number >= s.fc.n {
+		return false, fmt.Errorf("invalid share id: %d", share.Number)
+	}
+	if share.missing() {
+		return false, fmt.Errorf("share %d has no data", share.Number)
+	}
+	if s.seen[share.Number] {
+		return false, errDuplicateShare
+	}
+	s.seen[share.Number] = true
+	s.shares = append(s.shares, ShareOf[E]{
+		Number: share.Number,
+		Data:   s.fc.f.Add(s.fc.f.Zero(), share.Data),
+	})
+
+	k := s.fc.k
+	for s.r <= s.t && len(s.shares) >= k+s.t+s.r {
+		poly, err := s.fc.berlekampWelch(s.shares, s.r)
+		if err == nil && s.fc.agreement(s.shares, poly) >= k+s.t {
+			sort.Sort(byNumber[E](s.shares))
+			s.result, err = s.fc.newResult(s.shares, poly)
+			if err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		// more than r of the received shares are wrong
+		s.r++
+	}
+	if s.r > s.t {
+		return false, tooManyErrors
+	}
+	return false, nil
+}
+
+// Done reports whether the session has decoded the shares.
+func (s *OECSessionOf[E]) Done() bool {
+	return s.result != nil
+}
+
+// Received returns the number of shares fed into the session so far.
+func (s *OECSessionOf[E]) Received() int {
+	return len(s.shares)
+}
+
+// Result returns the decoded message and all n corrected shares. Its Faulty
+// list only covers the shares received before decoding succeeded.
+func (s *OECSessionOf[E]) Result() (*CorrectionResultOf[E], error) {
+	if s.result == nil {
+		return nil, errNotDecoded
+	}
+	return s.result, nil
+}
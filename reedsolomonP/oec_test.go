@@ -0,0 +1,105 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"testing"
+
+	"oec/field"
+)
+
+func sharesEqual(a, b []Share) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Number != b[i].Number || a[i].Data.Cmp(b[i].Data) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCorrect(t *testing.T) {
+	p := big.NewInt(29)
+	fc, err := NewRSGFp(3, 7, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := fc.Encode([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)})
+
+	for errs := 0; errs <= 2; errs++ {
+		shares, _ := fc.Encode([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)})
+		for i := 0; i < errs; i++ {
+			shares[2*i+1].Data = field.NewPrime(p).Add(shares[2*i+1].Data, BigOne)
+		}
+		got, err := fc.Correct(shares)
+		if err != nil {
+			t.Fatalf("%d errors: %v", errs, err)
+		}
+		if !sharesEqual(got, want) {
+			t.Errorf("%d errors: expected %v, got %v", errs, want, got)
+		}
+	}
+}
+
+func TestOECSession(t *testing.T) {
+	p := big.NewInt(101)
+	tt := 2
+	n := 3*tt + 1
+	fc, err := NewRSGFp(tt+1, n, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := fc.Encode([]*big.Int{big.NewInt(7), big.NewInt(3), big.NewInt(5)})
+
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(7), big.NewInt(3), big.NewInt(5)})
+	// the first two shares to arrive are corrupted
+	shares[0].Data = big.NewInt(1)
+	shares[1].Data = big.NewInt(2)
+
+	s, err := fc.NewOECSession(tt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, share := range shares {
+		done, err := s.Add(share)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 2t+1+r shares are needed with r = 2 errors
+		if done != (i+1 >= 2*tt+1+2) {
+			t.Fatalf("unexpected state after %d shares: done=%v", i+1, done)
+		}
+	}
+	got, err := s.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sharesEqual(got.Codeword, want) {
+		t.Errorf("expected %v, got %v", want, got.Codeword)
+	}
+	if len(got.Faulty) != 2 || got.Faulty[0] != 0 || got.Faulty[1] != 1 {
+		t.Errorf("expected faulty shares [0 1], got %v", got.Faulty)
+	}
+}
+
+func TestOECSession_Errors(t *testing.T) {
+	p := big.NewInt(101)
+	fc, _ := NewRSGFp(2, 4, p)
+
+	if _, err := fc.NewOECSession(2); err == nil {
+		t.Errorf("expected error for t too large")
+	}
+
+	s, _ := fc.NewOECSession(1)
+	if _, err := s.Result(); err == nil {
+		t.Errorf("expected error before decoding")
+	}
+	if _, err := s.Add(Share{Number: 4, Data: big.NewInt(1)}); err == nil {
+		t.Errorf("expected error for invalid share id")
+	}
+	s.Add(Share{Number: 0, Data: big.NewInt(1)})
+	if _, err := s.Add(Share{Number: 0, Data: big.NewInt(1)}); err != errDuplicateShare {
+		t.Errorf("expected errDuplicateShare, got %v", err)
+	}
+}
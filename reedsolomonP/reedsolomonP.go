@@ -5,33 +5,202 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+
+	"oec/field"
+	"oec/utils"
 )
 
+// RSGF online-error correction algorithm over the finite field f.
+// k required pieces and n total pieces.
+type RSGF[E any] struct {
+	k          int
+	n          int
+	f          field.Field[E]
+	xs         []E
+	encMatrix  MatrixOf[E]
+	ntt        *nttPlan[E]
+	tree       *utils.SubproductTree[E]
+	decoder    Decoder
+	systematic bool
+}
+
+// subproductThreshold is the number of points from which Encode, Rebuild and
+// the Gao decoder evaluate and interpolate with a subproduct tree.
+const subproductThreshold = 256
+
 // RSGFp online-error correction algorithm in modulo P Field
 // k required pieces and n total pieces.
-type RSGFp struct {
-	k         int
-	n         int
-	encMatrix P
-	p         *big.Int
+type RSGFp = RSGF[*big.Int]
+
+// RSGF64 online-error correction algorithm over a word-sized prime field.
+// Its shares hold field.Prime64 elements, which are in Montgomery form.
+type RSGF64 = RSGF[uint64]
+
+// RSGF2m online-error correction algorithm over the binary field GF(2^m),
+// whose shares are m-bit symbols.
+type RSGF2m = RSGF[uint16]
+
+// Decoder selects the algorithm Correct uses to decode the shares.
+type Decoder int
+
+const (
+	// BerlekampWelchDecoder runs Berlekamp-Welch for a growing number of errors.
+	BerlekampWelchDecoder Decoder = iota
+	// GaoDecoder runs Gao's partial extended Euclidean algorithm once.
+	GaoDecoder
+	// SyndromeDecoder runs Berlekamp-Massey, Chien search and Forney's formula.
+	SyndromeDecoder
+	// SugiyamaDecoder solves the key equation with the extended Euclidean algorithm.
+	SugiyamaDecoder
+)
+
+type options struct {
+	decoder    Decoder
+	domain     any
+	systematic bool
+}
+
+// Option configures a code created by NewRSGF or NewRSGFp.
+type Option func(*options)
+
+// WithDecoder selects the decoding algorithm used by Correct.
+// The default is BerlekampWelchDecoder.
+func WithDecoder(d Decoder) Option {
+	return func(o *options) {
+		o.decoder = d
+	}
+}
+
+// WithSystematic makes the code systematic: the first k shares are the input
+// of Encode itself, so the data stays readable without decoding. The
+// encoding matrix becomes [I | A], the Vandermonde matrix multiplied by the
+// inverse of its top k x k block. Share i is still the evaluation of a
+// polynomial of degree < k at the i-th point, the one through the input
+// values at the first k points.
+func WithSystematic() Option {
+	return func(o *options) {
+		o.systematic = true
+	}
+}
+
+// NewRSGFp returns a code over GF(p) with k required and n total pieces.
+func NewRSGFp(k, n int, p *big.Int, opts ...Option) (*RSGFp, error) {
+	return NewRSGF[*big.Int](k, n, field.NewPrime(p), opts...)
 }
 
-func NewRSGFp(k, n int, p *big.Int) (*RSGFp, error) {
+// NewRSGF64 returns a code over GF(p) for an odd prime p < 2^64 that does
+// its arithmetic on machine words instead of big.Int values.
+func NewRSGF64(k, n int, p uint64, opts ...Option) (*RSGF64, error) {
+	f, err := field.NewPrime64(p)
+	if err != nil {
+		return nil, err
+	}
+	return NewRSGF[uint64](k, n, f, opts...)
+}
+
+// NewRSGF2m returns a code over GF(2^m) defined by the primitive polynomial
+// poly, see field.NewGF2m. It requires n <= 2^m, and n < 2^m for the
+// SyndromeDecoder and SugiyamaDecoder.
+func NewRSGF2m(k, n, m int, poly uint32, opts ...Option) (*RSGF2m, error) {
+	f, err := field.NewGF2m(m, poly)
+	if err != nil {
+		return nil, err
+	}
+	return NewRSGF[uint16](k, n, f, opts...)
+}
+
+// NewRSGF returns a code over the field f with k required and n total pieces.
+// Share i is the evaluation of the message polynomial at the i-th point of
+// the evaluation domain, by default the element encoded by i+1.
+func NewRSGF[E any](k, n int, f field.Field[E], opts ...Option) (*RSGF[E], error) {
 	if k <= 0 || n <= 0 || k > n {
-		return nil, errors.New("requires 1 <= k <= n <= 256")
+		return nil, errors.New("requires 1 <= k <= n")
+	}
+	o := options{decoder: BerlekampWelchDecoder}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.decoder < BerlekampWelchDecoder || o.decoder > SugiyamaDecoder {
+		return nil, errUnknownDecoder
 	}
 
-	encMatrix, err := VandermondeP(n, k, p)
+	domain := ConsecutiveDomain[E](1)
+	if o.domain != nil {
+		d, ok := o.domain.(EvaluationDomain[E])
+		if !ok {
+			return nil, errors.New("evaluation domain does not match the field")
+		}
+		domain = d
+	}
+	xs, err := domain.Points(f, n)
+	if err != nil {
+		return nil, err
+	}
+	// the Chien search needs an element outside the domain, see nonzero
+	if (o.decoder == SyndromeDecoder || o.decoder == SugiyamaDecoder) && f.Order().Cmp(big.NewInt(int64(n))) == 0 {
+		return nil, errors.New("decoder requires an evaluation domain smaller than the field")
+	}
+	fc := &RSGF[E]{
+		k:          k,
+		n:          n,
+		f:          f,
+		xs:         xs,
+		ntt:        newNTTPlan(f, xs),
+		decoder:    o.decoder,
+		systematic: o.systematic,
+	}
+	if fc.ntt == nil && n >= subproductThreshold {
+		fc.tree = utils.NewSubproductTree(f, xs)
+	}
+	// only the dense paths of Encode and Rebuild read the encoding matrix
+	if fc.dense() {
+		if fc.encMatrix, err = Vandermonde(f, xs, k); err != nil {
+			return nil, err
+		}
+		if o.systematic {
+			if fc.encMatrix, err = systematicMatrix(f, fc.encMatrix, k); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fc, nil
+}
+
+// dense reports whether Rebuild inverts rows of the encoding matrix rather
+// than interpolating, which it does below subproductThreshold without a
+// number-theoretic transform. Encode then multiplies by the matrix unless n
+// reaches the threshold.
+func (fc *RSGF[E]) dense() bool {
+	return fc.ntt == nil && fc.k < subproductThreshold
+}
+
+// systematicMatrix returns m times the inverse of its top k x k block, whose
+// first k rows are the identity.
+func systematicMatrix[E any](f field.Field[E], m MatrixOf[E], k int) (MatrixOf[E], error) {
+	top, err := m.SubMatrix(0, 0, k, k)
+	if err != nil {
+		return nil, err
+	}
+	topInv, err := top.Invert(f)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println(encMatrix.String())
-	return &RSGFp{
-		k:         k,
-		n:         n,
-		encMatrix: encMatrix,
-		p:         p,
-	}, nil
+	return m.Multiply(f, topInv)
+}
+
+// Systematic reports whether the first k shares are the input of Encode.
+func (fc *RSGF[E]) Systematic() bool {
+	return fc.systematic
+}
+
+// Field returns the field the code is defined over.
+func (fc *RSGF[E]) Field() field.Field[E] {
+	return fc.f
+}
+
+// Points returns the evaluation points of the n shares.
+func (fc *RSGF[E]) Points() []E {
+	return append([]E{}, fc.xs...)
 }
 
 // Encode will take input data and encode to the total number of pieces n this
@@ -39,20 +208,35 @@ func NewRSGFp(k, n int, p *big.Int) (*RSGFp, error) {
 //
 // The input data must be a multiple of the required number of pieces k.
 // Padding to this multiple is up to the caller.
-func (fc *RSGFp) Encode(input []*big.Int) ([]Share, error) {
+func (fc *RSGF[E]) Encode(input []E) ([]ShareOf[E], error) {
 	if len(input) < fc.k {
 		return nil, errTooFewShards
 	}
-	output := make([]Share, fc.n)
+	if fc.ntt != nil || fc.tree != nil {
+		coeff := input[:fc.k]
+		if fc.systematic {
+			numbers := make([]int, fc.k)
+			for i := range numbers {
+				numbers[i] = i
+			}
+			c, err := fc.interpolate(numbers, coeff)
+			if err != nil {
+				return nil, err
+			}
+			coeff = c
+		}
+		return fc.evaluate(coeff)
+	}
 
+	f := fc.f
+	output := make([]ShareOf[E], fc.n)
 	for i := 0; i < fc.n; i++ {
-		fecBuf := new(big.Int).SetInt64(0)
+		fecBuf := f.Zero()
 		for j := 0; j < fc.k; j++ {
-			fecBuf = new(big.Int).Add(fecBuf, new(big.Int).Mul(input[j], fc.encMatrix[i][j]))
-			fecBuf.Mod(fecBuf, fc.p)
+			fecBuf = f.Add(fecBuf, f.Mul(input[j], fc.encMatrix[i][j]))
 		}
 
-		output[i] = Share{
+		output[i] = ShareOf[E]{
 			Number: i,
 			Data:   fecBuf,
 		}
@@ -60,6 +244,38 @@ func (fc *RSGFp) Encode(input []*big.Int) ([]Share, error) {
 	return output, nil
 }
 
+// evaluate returns the n shares of the message polynomial with coefficients
+// coeff, which is what Encode does for a code that is not systematic.
+func (fc *RSGF[E]) evaluate(coeff []E) ([]ShareOf[E], error) {
+	f := fc.f
+	var values []E
+	switch {
+	case fc.ntt != nil:
+		v, err := fc.ntt.encode(f, coeff, fc.n)
+		if err != nil {
+			return nil, err
+		}
+		values = v
+	case fc.tree != nil:
+		v, err := fc.tree.Eval(utils.NewPolyOf(f, coeff...))
+		if err != nil {
+			return nil, err
+		}
+		values = v
+	default:
+		poly := utils.NewPolyOf(f, coeff...)
+		values = make([]E, fc.n)
+		for i, x := range fc.xs {
+			values[i] = poly.Eval(x)
+		}
+	}
+	output := make([]ShareOf[E], fc.n)
+	for i := range output {
+		output[i] = ShareOf[E]{Number: i, Data: values[i]}
+	}
+	return output, nil
+}
+
 // Rebuild will take a list of corrected shares (pieces) and a callback output.
 // output will be called k times ((*FEC).Required() times) with 1/k of the
 // original data each time and the index of that data piece.
@@ -72,77 +288,118 @@ func (fc *RSGFp) Encode(input []*big.Int) ([]Share, error) {
 // output returns.
 //
 // Rebuild assumes that you have already called Correct or did not need to.
-func (fc *RSGFp) Rebuild(shares []Share, output func(Share)) error {
+func (fc *RSGF[E]) Rebuild(shares []ShareOf[E], output func(ShareOf[E])) error {
 	k := fc.k
 	n := fc.n
+	f := fc.f
 	encMatrix := fc.encMatrix
 
 	if len(shares) < k {
 		return errTooFewShards
 	}
 
-	sort.Sort(byNumber(shares))
-	fmt.Println(shares)
-
-	// Initialize the decoding matrix and vectors
-	var mDec P
-	mDec = make([][]*big.Int, k)
-	for i := range mDec {
-		mDec[i] = make([]*big.Int, k)
+	sort.Sort(byNumber[E](shares))
+	shares = shares[:k]
+	for i, share := range shares {
+		if share.Number < 0 || share.Number >= n {
+			return fmt.Errorf("invalid share id: %d", share.Number)
+		}
+		if i > 0 && share.Number == shares[i-1].Number {
+			return errDuplicateShare
+		}
 	}
-	indexes := make([]int, k)
-	sharesv := make([]*big.Int, k)
 
-	// Fill the decoding matrix and vectors
-	for i := 0; i < k; i++ {
-		share := shares[i]
-		if share.Number >= n {
-			return fmt.Errorf("invalid share id: %d", share.Number)
+	// the shares of a systematic code below k are the data pieces
+	known := make(map[int]E)
+	if fc.systematic {
+		for _, share := range shares {
+			if share.Number < k {
+				known[share.Number] = share.Data
+			}
 		}
+	}
+	if len(known) < k && !fc.dense() {
+		return fc.rebuildInterpolate(shares, output)
+	}
 
-		if share.Number < k {
-			mDec[i][share.Number] = BigOne
-		} else {
+	// Data piece i is row i of the inverse of the rows of the encoding
+	// matrix of the shares, applied to the share values.
+	var invMDec MatrixOf[E]
+	if len(known) < k {
+		mDec, _ := newMatrix(f, k, k)
+		for i, share := range shares {
 			copy(mDec[i], encMatrix[share.Number][:k])
 		}
-
-		sharesv[i] = share.Data
-		indexes[i] = share.Number
+		var err error
+		if invMDec, err = mDec.Invert(f); err != nil {
+			return err
+		}
 	}
-	fmt.Println(mDec)
+	for i := 0; i < k; i++ {
+		data, ok := known[i]
+		if !ok {
+			data = f.Zero()
+			for j, share := range shares {
+				data = f.Add(data, f.Mul(share.Data, invMDec[i][j]))
+			}
+		}
+		if output != nil {
+			output(ShareOf[E]{
+				Number: i,
+				Data:   data,
+			})
+		}
+	}
+	return nil
+}
 
-	invMDec, err := mDec.Invert(fc.p)
+// rebuildInterpolate recovers the message from k shares by interpolation
+// instead of inverting a matrix, with the number-theoretic transform when the
+// domain allows it and with a subproduct tree otherwise. For a systematic
+// code the data pieces are the values of the interpolated polynomial at the
+// first k points.
+func (fc *RSGF[E]) rebuildInterpolate(shares []ShareOf[E], output func(ShareOf[E])) error {
+	numbers := make([]int, len(shares))
+	ys := make([]E, len(shares))
+	for i, share := range shares {
+		numbers[i] = share.Number
+		ys[i] = share.Data
+	}
+	message, err := fc.interpolate(numbers, ys)
 	if err != nil {
 		return err
 	}
-	fmt.Println(invMDec)
-	fmt.Println(sharesv)
-	// Solve the system of linear equations to find the original data
-	originalData := make([]*big.Int, k)
-	for i := 0; i < k; i++ {
-		if indexes[i] < k {
-			originalData[indexes[i]] = sharesv[i]
-			if output != nil {
-				output(Share{
-					Number: indexes[i],
-					Data:   sharesv[i],
-				})
-			}
-		} else {
-			buf := big.NewInt(0)
-			for j := 0; j < k; j++ {
-				product := new(big.Int).Mul(sharesv[j], invMDec[i][j])
-				buf.Add(buf, product)
-				buf.Mod(buf, fc.p)
-			}
-			originalData[i] = buf
-			if output != nil {
-				output(Share{
-					Number: i,
-					Data:   buf,
-				})
-			}
+	if fc.systematic {
+		codeword, err := fc.evaluate(message)
+		if err != nil {
+			return err
+		}
+		for i := range message {
+			message[i] = codeword[i].Data
+		}
+	}
+	if output != nil {
+		for i, m := range message {
+			output(ShareOf[E]{Number: i, Data: m})
 		}
 	}
 	return nil
 }
+
+// interpolate returns the len(numbers) coefficients of the polynomial with
+// value ys[i] at the point of share numbers[i]. The numbers must be distinct
+// and sorted.
+func (fc *RSGF[E]) interpolate(numbers []int, ys []E) ([]E, error) {
+	if fc.ntt != nil {
+		return fc.ntt.interpolate(fc.f, numbers, ys)
+	}
+	xs := make([]E, len(numbers))
+	for i, number := range numbers {
+		xs[i] = fc.xs[number]
+	}
+	poly, err := utils.FastInterpolate(fc.f, xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	return poly.Coefficients(len(numbers)), nil
+}
@@ -0,0 +1,48 @@
+package reedsolomonP
+
+import "math/big"
+
+// CorrectionResultOf describes the outcome of decoding a set of shares.
+type CorrectionResultOf[E any] struct {
+	// Message holds the k decoded message values, the input of Encode.
+	Message []E
+	// Codeword holds all n corrected shares.
+	Codeword []ShareOf[E]
+	// Faulty lists the Number of every received share that disagrees with
+	// Codeword, in increasing order.
+	Faulty []int
+	// Corrected is the number of errors that were fixed.
+	Corrected int
+}
+
+// CorrectionResult is the outcome of decoding shares over GF(p).
+type CorrectionResult = CorrectionResultOf[*big.Int]
+
+// newResult re-encodes the decoded message polynomial and compares the
+// received shares against it. For a systematic code the message is the
+// first k shares of the codeword instead of the coefficients.
+func (fc *RSGF[E]) newResult(received []ShareOf[E], pPoly []E) (*CorrectionResultOf[E], error) {
+	codeword, err := fc.evaluate(pPoly)
+	if err != nil {
+		return nil, err
+	}
+	message := pPoly
+	if fc.systematic {
+		message = make([]E, fc.k)
+		for i := range message {
+			message[i] = codeword[i].Data
+		}
+	}
+	var faulty []int
+	for _, share := range received {
+		if !fc.f.Equal(share.Data, codeword[share.Number].Data) {
+			faulty = append(faulty, share.Number)
+		}
+	}
+	return &CorrectionResultOf[E]{
+		Message:   message,
+		Codeword:  codeword,
+		Faulty:    faulty,
+		Corrected: len(faulty),
+	}, nil
+}
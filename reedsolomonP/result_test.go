@@ -0,0 +1,39 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"testing"
+
+	"oec/field"
+)
+
+func TestDecode_Result(t *testing.T) {
+	p := big.NewInt(257)
+	input := []*big.Int{big.NewInt(10), big.NewInt(20), big.NewInt(30)}
+
+	for _, d := range []Decoder{BerlekampWelchDecoder, GaoDecoder, SyndromeDecoder, SugiyamaDecoder} {
+		fc, _ := NewRSGFp(3, 9, p, WithDecoder(d))
+		want, _ := fc.Encode(input)
+
+		shares, _ := fc.Encode(input)
+		shares[7].Data = big.NewInt(0)
+		shares[2].Data = field.NewPrime(p).Add(shares[2].Data, BigTwo)
+		shares[4].Data = nil
+
+		result, err := fc.DecodeWithErasures(shares, []int{5})
+		if err != nil {
+			t.Fatalf("decoder %d: %v", d, err)
+		}
+		for i := range input {
+			if result.Message[i].Cmp(input[i]) != 0 {
+				t.Errorf("decoder %d: expected message %v, got %v", d, input, result.Message)
+			}
+		}
+		if !sharesEqual(result.Codeword, want) {
+			t.Errorf("decoder %d: expected codeword %v, got %v", d, want, result.Codeword)
+		}
+		if result.Corrected != 2 || len(result.Faulty) != 2 || result.Faulty[0] != 2 || result.Faulty[1] != 7 {
+			t.Errorf("decoder %d: expected faulty shares [2 7], got %v", d, result.Faulty)
+		}
+	}
+}
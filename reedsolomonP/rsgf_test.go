@@ -0,0 +1,220 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"oec/field"
+)
+
+func TestNewRSGF(t *testing.T) {
+	f := field.NewPrime(big.NewInt(7))
+	if _, err := NewRSGF[*big.Int](3, 8, f); err == nil {
+		t.Errorf("expected error for n > field order")
+	}
+
+	fc, err := NewRSGF[*big.Int](2, 6, f, WithDecoder(SugiyamaDecoder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fc.Field() != field.Field[*big.Int](f) {
+		t.Errorf("unexpected field")
+	}
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(3), big.NewInt(5)})
+	want, _ := fc.Encode([]*big.Int{big.NewInt(3), big.NewInt(5)})
+	shares[1].Data = f.Add(shares[1].Data, f.One())
+	shares[4].Data = f.Add(shares[4].Data, f.FromInt64(3))
+
+	result, err := fc.Decode(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sharesEqual(result.Codeword, want) {
+		t.Errorf("expected %v, got %v", want, result.Codeword)
+	}
+	if len(result.Faulty) != 2 || result.Faulty[0] != 1 || result.Faulty[1] != 4 {
+		t.Errorf("expected faulty shares [1 4], got %v", result.Faulty)
+	}
+}
+
+func TestRSGF64(t *testing.T) {
+	p := uint64(1<<61 - 1)
+	rnd := rand.New(rand.NewSource(9))
+	for _, d := range []Decoder{BerlekampWelchDecoder, GaoDecoder, SyndromeDecoder, SugiyamaDecoder} {
+		fc, err := NewRSGF64(4, 12, p, WithDecoder(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := fc.Field()
+		input := make([]uint64, 4)
+		for i := range input {
+			input[i] = f.FromInt64(rnd.Int63())
+		}
+		want, _ := fc.Encode(input)
+		shares, _ := fc.Encode(input)
+		for _, i := range []int{0, 5, 11, 7} {
+			shares[i].Data = f.Add(shares[i].Data, f.FromInt64(rnd.Int63n(1000)+1))
+		}
+
+		result, err := fc.Decode(shares)
+		if err != nil {
+			t.Fatalf("decoder %d: %v", d, err)
+		}
+		for i := range want {
+			if result.Codeword[i] != want[i] {
+				t.Fatalf("decoder %d: expected %v, got %v", d, want, result.Codeword)
+			}
+		}
+		if result.Corrected != 4 {
+			t.Errorf("decoder %d: expected 4 corrected shares, got %d", d, result.Corrected)
+		}
+	}
+
+	if _, err := NewRSGF64(2, 4, 15); err == nil {
+		t.Errorf("expected error for composite modulus")
+	}
+}
+
+func benchmarkEncode[E any](b *testing.B, fc *RSGF[E]) {
+	f := fc.Field()
+	input := make([]E, fc.k)
+	for i := range input {
+		input[i] = f.FromInt64(int64(i*7919 + 1))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fc.Encode(input)
+	}
+}
+
+func benchmarkCorrect[E any](b *testing.B, fc *RSGF[E]) {
+	f := fc.Field()
+	input := make([]E, fc.k)
+	for i := range input {
+		input[i] = f.FromInt64(int64(i*7919 + 1))
+	}
+	shares, _ := fc.Encode(input)
+	for i := 0; i < (fc.n-fc.k)/2; i++ {
+		shares[2*i].Data = f.Add(shares[2*i].Data, f.One())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fc.Correct(shares); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	p := uint64(1<<61 - 1)
+	b.Run("RSGFp", func(b *testing.B) {
+		fc, _ := NewRSGFp(1024, 3072, new(big.Int).SetUint64(p))
+		benchmarkEncode(b, fc)
+	})
+	b.Run("RSGF64", func(b *testing.B) {
+		fc, _ := NewRSGF64(1024, 3072, p)
+		benchmarkEncode(b, fc)
+	})
+	b.Run("RSGF64/NTT", func(b *testing.B) {
+		fc, _ := NewRSGF64(1024, 3072, 0xffffffff00000001, WithDomain(RootsOfUnityDomain[uint64]()))
+		benchmarkEncode(b, fc)
+	})
+}
+
+func BenchmarkCorrect(b *testing.B) {
+	p := uint64(1<<61 - 1)
+	b.Run("RSGFp", func(b *testing.B) {
+		fc, _ := NewRSGFp(16, 64, new(big.Int).SetUint64(p), WithDecoder(SugiyamaDecoder))
+		benchmarkCorrect(b, fc)
+	})
+	b.Run("RSGF64", func(b *testing.B) {
+		fc, _ := NewRSGF64(16, 64, p, WithDecoder(SugiyamaDecoder))
+		benchmarkCorrect(b, fc)
+	})
+}
+
+func TestRSGF2m(t *testing.T) {
+	rnd := rand.New(rand.NewSource(11))
+	for _, d := range []Decoder{BerlekampWelchDecoder, GaoDecoder, SyndromeDecoder, SugiyamaDecoder} {
+		fc, err := NewRSGF2m(10, 40, 8, 0, WithDecoder(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		input := make([]uint16, 10)
+		for i := range input {
+			input[i] = uint16(rnd.Intn(256))
+		}
+		want, _ := fc.Encode(input)
+		shares, _ := fc.Encode(input)
+		// 2e + s <= n - k with 12 errors and 6 erasures
+		for _, i := range rnd.Perm(40)[:18] {
+			shares[i].Data ^= uint16(rnd.Intn(255) + 1)
+		}
+		var erasures []int
+		for i := range shares {
+			if len(erasures) < 6 && shares[i].Data != want[i].Data {
+				erasures = append(erasures, i)
+			}
+		}
+
+		got, err := fc.CorrectWithErasures(shares, erasures)
+		if err != nil {
+			t.Fatalf("decoder %d: %v", d, err)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("decoder %d: expected %v, got %v", d, want, got)
+			}
+		}
+
+		message := make([]uint16, 10)
+		err = fc.Rebuild(got[10:20], func(s ShareOf[uint16]) {
+			message[s.Number] = s.Data
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range input {
+			if message[i] != input[i] {
+				t.Fatalf("decoder %d: rebuilt %v, expected %v", d, message, input)
+			}
+		}
+	}
+
+	if _, err := NewRSGF2m(4, 17, 4, 0); err == nil {
+		t.Errorf("expected error for n > 2^m")
+	}
+	for _, d := range []Decoder{SyndromeDecoder, SugiyamaDecoder} {
+		if _, err := NewRSGF2m(4, 16, 4, 0x13, WithDecoder(d)); err == nil {
+			t.Errorf("decoder %d: expected error for n = 2^m", d)
+		}
+	}
+}
+
+func TestRSGF2m_ListDecode(t *testing.T) {
+	fc, err := NewRSGF2m(3, 15, 4, 0x13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := []uint16{7, 1, 12}
+	shares, _ := fc.Encode(input)
+	// 7 errors are beyond the unique decoding radius of 6
+	for i := 0; i < 7; i++ {
+		shares[2*i].Data ^= uint16(i + 1)
+	}
+
+	results, err := fc.ListDecode(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, r := range results {
+		if r.Message[0] == input[0] && r.Message[1] == input[1] && r.Message[2] == input[2] {
+			found = r.Corrected == 7
+		}
+	}
+	if !found {
+		t.Errorf("message %v not in list %v", input, results)
+	}
+}
@@ -2,15 +2,25 @@ package reedsolomonP
 
 import "math/big"
 
-// A Share represents a piece of the FEC-encoded data.
-// Both fields are required.
-type Share struct {
+// A ShareOf represents a piece of the FEC-encoded data over a field with
+// elements of type E. Both fields are required.
+type ShareOf[E any] struct {
 	Number int
-	Data   *big.Int
+	Data   E
 }
 
-type byNumber []Share
+// A Share represents a piece of the FEC-encoded data over GF(p).
+type Share = ShareOf[*big.Int]
 
-func (b byNumber) Len() int               { return len(b) }
-func (b byNumber) Less(i int, j int) bool { return b[i].Number < b[j].Number }
-func (b byNumber) Swap(i int, j int)      { b[i], b[j] = b[j], b[i] }
+type byNumber[E any] []ShareOf[E]
+
+func (b byNumber[E]) Len() int               { return len(b) }
+func (b byNumber[E]) Less(i int, j int) bool { return b[i].Number < b[j].Number }
+func (b byNumber[E]) Swap(i int, j int)      { b[i], b[j] = b[j], b[i] }
+
+// missing reports whether the share carries no data. Of the element types of
+// package field only *big.Int can be nil.
+func (s ShareOf[E]) missing() bool {
+	d, ok := any(s.Data).(*big.Int)
+	return ok && d == nil
+}
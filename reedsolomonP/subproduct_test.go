@@ -0,0 +1,89 @@
+package reedsolomonP
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// denseMatrix returns the encoding matrix of the dense path of fc, which
+// codes on the fast paths do not build.
+func denseMatrix[E any](t *testing.T, fc *RSGF[E]) MatrixOf[E] {
+	m, err := Vandermonde(fc.f, fc.xs, fc.k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fc.systematic {
+		if m, err = systematicMatrix(fc.f, m, fc.k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestSubproductFastPath(t *testing.T) {
+	rnd := rand.New(rand.NewSource(13))
+	k, n := 260, 300
+	fc, err := NewRSGF64(k, n, 1<<61-1, WithDecoder(GaoDecoder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fc.ntt != nil || fc.tree == nil || fc.encMatrix != nil {
+		t.Fatalf("subproduct tree not selected")
+	}
+	encMatrix := denseMatrix(t, fc)
+	f := fc.Field()
+	input := make([]uint64, k)
+	for i := range input {
+		input[i] = f.FromInt64(rnd.Int63())
+	}
+	shares, err := fc.Encode(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, share := range shares {
+		want := f.Zero()
+		for j := range input {
+			want = f.Add(want, f.Mul(input[j], encMatrix[i][j]))
+		}
+		if share.Data != want {
+			t.Fatalf("share %d differs from the dense encoding", i)
+		}
+	}
+
+	message := make([]uint64, k)
+	err = fc.Rebuild(append([]ShareOf[uint64]{}, shares[n-k:]...), func(s ShareOf[uint64]) {
+		message[s.Number] = s.Data
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range input {
+		if message[i] != input[i] {
+			t.Fatalf("rebuilt message differs at %d", i)
+		}
+	}
+
+	received := append([]ShareOf[uint64]{}, shares...)
+	for _, i := range rnd.Perm(n)[:(n-k)/2] {
+		received[i].Data = f.Add(received[i].Data, f.One())
+	}
+	result, err := fc.Decode(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalMessage(result.Message, input) || result.Corrected != (n-k)/2 {
+		t.Errorf("Gao decoding failed, corrected %d", result.Corrected)
+	}
+}
+
+func equalMessage[E comparable](a, b []E) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
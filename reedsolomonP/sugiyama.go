@@ -0,0 +1,79 @@
+package reedsolomonP
+
+import (
+	"oec/utils"
+)
+
+// SugiyamaDecode corrects up to (len(shares)-k)/2 errors by solving the key
+// equation Λ(z)S(z) = Ω(z) mod z^(m-k) with the extended Euclidean algorithm.
+// Syndromes, Chien search and Forney's formula are shared with SyndromeDecode,
+// so the two only differ in how the error locator is found.
+func (fc *RSGF[E]) SugiyamaDecode(shares []ShareOf[E]) ([]ShareOf[E], []int, error) {
+	pPoly, errPos, err := fc.sugiyamaDecode(shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, err := fc.evaluate(pPoly)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, errPos, nil
+}
+
+// sugiyamaDecode returns the coefficients of the message polynomial P and the
+// numbers of the erroneous shares.
+func (fc *RSGF[E]) sugiyamaDecode(shares []ShareOf[E]) ([]E, []int, error) {
+	xs, ys, err := fc.points(shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	shifted, err := fc.nonzero(xs)
+	if err != nil {
+		return nil, nil, err
+	}
+	synd, err := fc.syndromes(shifted, ys)
+	if err != nil {
+		return nil, nil, err
+	}
+	locator, err := fc.keyEquation(synd)
+	if err != nil {
+		return nil, nil, err
+	}
+	corrected, errPos, err := fc.correctErrors(shares, shifted, ys, synd, locator)
+	if err != nil {
+		return nil, nil, err
+	}
+	poly, err := fc.message(xs, corrected)
+	if err != nil {
+		return nil, nil, err
+	}
+	return poly, errPos, nil
+}
+
+// keyEquation returns the error locator Λ with Λ(0) = 1. The extended
+// Euclidean algorithm on z^N and S(z) stops at the first remainder Ω of
+// degree below N/2; its cofactor of S(z) is a multiple of Λ.
+func (fc *RSGF[E]) keyEquation(synd []E) ([]E, error) {
+	f := fc.f
+	s := utils.NewPolyOf(f, synd...)
+	if s.IsZero() {
+		return []E{f.One()}, nil
+	}
+
+	N := len(synd)
+	zN := utils.MonomialOf(f, f.One(), N)
+
+	_, _, t, err := zN.ExtendedGCD(s, (N+1)/2)
+	if err != nil {
+		return nil, err
+	}
+	t0 := t.Coefficient(0)
+	if f.IsZero(t0) {
+		return nil, tooManyErrors
+	}
+	t0Inv, err := f.Inv(t0)
+	if err != nil {
+		return nil, err
+	}
+	return t.Scale(t0Inv).Coeff, nil
+}
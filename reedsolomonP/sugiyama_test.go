@@ -0,0 +1,61 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"oec/field"
+)
+
+// TestDecoders_CrossCheck runs every decoder on the same received words.
+func TestDecoders_CrossCheck(t *testing.T) {
+	p := big.NewInt(1009)
+	rnd := rand.New(rand.NewSource(4))
+	decoders := []Decoder{BerlekampWelchDecoder, GaoDecoder, SyndromeDecoder, SugiyamaDecoder}
+
+	for _, tc := range []struct{ k, n int }{{1, 2}, {2, 5}, {3, 7}, {5, 12}, {6, 17}} {
+		input := make([]*big.Int, tc.k)
+		for i := range input {
+			input[i] = big.NewInt(rnd.Int63n(p.Int64()))
+		}
+		for errs := 0; errs <= (tc.n-tc.k)/2; errs++ {
+			fc, _ := NewRSGFp(tc.k, tc.n, p)
+			want, _ := fc.Encode(input)
+			received, _ := fc.Encode(input)
+			corrupt(received, errs, p, rnd)
+
+			for _, d := range decoders {
+				fc, err := NewRSGFp(tc.k, tc.n, p, WithDecoder(d))
+				if err != nil {
+					t.Fatal(err)
+				}
+				shares := append([]Share{}, received...)
+				got, err := fc.Correct(shares)
+				if err != nil {
+					t.Fatalf("decoder %d k=%d n=%d errors=%d: %v", d, tc.k, tc.n, errs, err)
+				}
+				if !sharesEqual(got, want) {
+					t.Errorf("decoder %d k=%d n=%d errors=%d: expected %v, got %v", d, tc.k, tc.n, errs, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestSugiyamaDecode_ErrorPositions(t *testing.T) {
+	p := big.NewInt(257)
+	fc, _ := NewRSGFp(4, 11, p)
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(9), big.NewInt(8), big.NewInt(7), big.NewInt(6)})
+	shares[2].Data = field.NewPrime(p).Add(shares[2].Data, big.NewInt(5))
+	shares[9].Data = field.NewPrime(p).Add(shares[9].Data, big.NewInt(100))
+	shares[10].Data = field.NewPrime(p).Add(shares[10].Data, big.NewInt(1))
+
+	_, errPos, err := fc.SugiyamaDecode(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errPos) != 3 || errPos[0] != 2 || errPos[1] != 9 || errPos[2] != 10 {
+		t.Errorf("expected error positions [2 9 10], got %v", errPos)
+	}
+}
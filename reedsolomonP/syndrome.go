@@ -0,0 +1,271 @@
+package reedsolomonP
+
+import (
+	"errors"
+	"math/big"
+
+	"oec/field"
+	"oec/utils"
+)
+
+// SyndromeDecode corrects up to (len(shares)-k)/2 errors without inverting a
+// matrix. It computes the syndromes with a parity-check matrix, finds the error
+// locator with Berlekamp-Massey, its roots with a Chien search over the
+// evaluation points and the error magnitudes with Forney's formula.
+// It returns the corrected codeword of all n shares together with the numbers
+// of the shares that were found to be wrong.
+func (fc *RSGF[E]) SyndromeDecode(shares []ShareOf[E]) ([]ShareOf[E], []int, error) {
+	pPoly, errPos, err := fc.syndromeDecode(shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, err := fc.evaluate(pPoly)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, errPos, nil
+}
+
+// syndromeDecode returns the coefficients of the message polynomial P and the
+// numbers of the erroneous shares.
+func (fc *RSGF[E]) syndromeDecode(shares []ShareOf[E]) ([]E, []int, error) {
+	xs, ys, err := fc.points(shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	shifted, err := fc.nonzero(xs)
+	if err != nil {
+		return nil, nil, err
+	}
+	synd, err := fc.syndromes(shifted, ys)
+	if err != nil {
+		return nil, nil, err
+	}
+	locator := berlekampMassey(fc.f, synd)
+	corrected, errPos, err := fc.correctErrors(shares, shifted, ys, synd, locator)
+	if err != nil {
+		return nil, nil, err
+	}
+	poly, err := fc.message(xs, corrected)
+	if err != nil {
+		return nil, nil, err
+	}
+	return poly, errPos, nil
+}
+
+// points returns the evaluation points and the values of the shares.
+func (fc *RSGF[E]) points(shares []ShareOf[E]) ([]E, []E, error) {
+	if len(shares) < fc.k {
+		return nil, nil, errTooFewShards
+	}
+	xs := make([]E, len(shares))
+	ys := make([]E, len(shares))
+	seen := make(map[int]bool, len(shares))
+	for i, share := range shares {
+		if seen[share.Number] {
+			return nil, nil, errDuplicateShare
+		}
+		seen[share.Number] = true
+		xs[i] = fc.point(share.Number)
+		ys[i] = fc.f.Add(fc.f.Zero(), share.Data)
+	}
+	return xs, ys, nil
+}
+
+// nonzero returns the points xs translated by the first element c for which
+// no x+c is zero, so that the Chien search can invert them. Translating the
+// points maps the code to itself, with message P(x) becoming P(x-c).
+func (fc *RSGF[E]) nonzero(xs []E) ([]E, error) {
+	f := fc.f
+	order := f.Order()
+	for j := int64(0); big.NewInt(j).Cmp(order) < 0; j++ {
+		c := f.FromInt64(j)
+		shifted := make([]E, len(xs))
+		ok := true
+		for i, x := range xs {
+			shifted[i] = f.Add(x, c)
+			if f.IsZero(shifted[i]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return shifted, nil
+		}
+	}
+	return nil, errors.New("every field element is an evaluation point")
+}
+
+// ParityCheckP returns the (m-k) x m parity-check matrix H of the code over
+// GF(p) punctured to the evaluation points xs. See ParityCheck.
+func ParityCheckP(xs []*big.Int, k int, p *big.Int) (P, error) {
+	h, err := ParityCheck[*big.Int](field.NewPrime(p), xs, k)
+	return P(h), err
+}
+
+// ParityCheck returns the (m-k) x m parity-check matrix H of the code
+// punctured to the evaluation points xs, H[j][i] = v_i * x_i^j with
+// v_i = 1 / prod_{l != i} (x_i - x_l). Every codeword c satisfies H * c = 0.
+func ParityCheck[E any](f field.Field[E], xs []E, k int) (MatrixOf[E], error) {
+	m := len(xs)
+	h, err := newMatrix(f, m-k, m)
+	if err != nil {
+		return nil, err
+	}
+	for i := range xs {
+		den := f.One()
+		for l := range xs {
+			if l != i {
+				den = f.Mul(den, f.Sub(xs[i], xs[l]))
+			}
+		}
+		v, err := f.Inv(den)
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < m-k; j++ {
+			h[j][i] = v
+			v = f.Mul(v, xs[i])
+		}
+	}
+	return h, nil
+}
+
+// syndromes returns S_j = sum_i v_i * x_i^j * y_i for j = 0..m-k-1.
+func (fc *RSGF[E]) syndromes(xs, ys []E) ([]E, error) {
+	if len(xs) == fc.k {
+		// no redundancy, nothing to check
+		return nil, nil
+	}
+	h, err := ParityCheck(fc.f, xs, fc.k)
+	if err != nil {
+		return nil, err
+	}
+	col := make(MatrixOf[E], len(ys))
+	for i := range ys {
+		col[i] = []E{ys[i]}
+	}
+	s, err := h.Multiply(fc.f, col)
+	if err != nil {
+		return nil, err
+	}
+	synd := make([]E, len(s))
+	for j := range s {
+		synd[j] = s[j][0]
+	}
+	return synd, nil
+}
+
+// berlekampMassey returns the shortest connection polynomial C with C_0 = 1
+// that generates the sequence s, that is the error locator
+// Λ(z) = prod (1 - X_i z) for syndromes S_j = sum_i Y_i X_i^j.
+func berlekampMassey[E any](f field.Field[E], s []E) []E {
+	c := []E{f.One()}
+	b := []E{f.One()}
+	l := 0
+	m := 1
+	lastD := f.One()
+
+	for n := range s {
+		// discrepancy
+		d := s[n]
+		for i := 1; i <= l && i < len(c); i++ {
+			d = f.Add(d, f.Mul(c[i], s[n-i]))
+		}
+		if f.IsZero(d) {
+			m++
+			continue
+		}
+
+		coef, _ := field.Div(f, d, lastD)
+		next := make([]E, max(len(c), len(b)+m))
+		for i := range next {
+			next[i] = f.Zero()
+			if i < len(c) {
+				next[i] = c[i]
+			}
+			if i >= m && i-m < len(b) {
+				next[i] = f.Sub(next[i], f.Mul(coef, b[i-m]))
+			}
+		}
+		if 2*l <= n {
+			b = c
+			l = n + 1 - l
+			lastD = d
+			m = 1
+		} else {
+			m++
+		}
+		c = next
+	}
+	for len(c) < l+1 {
+		c = append(c, f.Zero())
+	}
+	return c[:l+1]
+}
+
+// correctErrors finds the roots of the error locator among the evaluation
+// points, which must be non-zero, and subtracts the error magnitudes given by
+// Forney's formula. It returns the corrected values and the numbers of the
+// erroneous shares.
+func (fc *RSGF[E]) correctErrors(shares []ShareOf[E], xs, ys, synd, locator []E) ([]E, []int, error) {
+	f := fc.f
+	nu := len(locator) - 1
+	if 2*nu > len(synd) {
+		return nil, nil, tooManyErrors
+	}
+
+	// Ω(z) = S(z) Λ(z) mod z^(m-k)
+	lambda := utils.NewPolyOf(f, locator...)
+	omega := utils.NewPolyOf(f, lambda.Mul(utils.NewPolyOf(f, synd...)).Coefficients(len(synd))...)
+	// formal derivative Λ'(z)
+	deriv := lambda.Derivative()
+
+	corrected := make([]E, len(ys))
+	copy(corrected, ys)
+	var errPos []int
+	for i, x := range xs {
+		// Chien search over the evaluation points: X_i^-1 is a root of Λ
+		xInv, err := f.Inv(x)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !f.IsZero(lambda.Eval(xInv)) {
+			continue
+		}
+		// Forney: Y_i = -X_i Ω(X_i^-1) / Λ'(X_i^-1) and e_i = Y_i / v_i
+		den, err := f.Inv(deriv.Eval(xInv))
+		if err != nil {
+			return nil, nil, tooManyErrors
+		}
+		y := f.Neg(f.Mul(f.Mul(x, omega.Eval(xInv)), den))
+		v := f.One()
+		for l := range xs {
+			if l != i {
+				v = f.Mul(v, f.Sub(x, xs[l]))
+			}
+		}
+		corrected[i] = f.Sub(corrected[i], f.Mul(y, v))
+		errPos = append(errPos, shares[i].Number)
+	}
+	if len(errPos) != nu {
+		return nil, nil, tooManyErrors
+	}
+	return corrected, errPos, nil
+}
+
+// message returns the coefficients of the message polynomial through the
+// corrected values ys at the points xs, checking that they form a codeword.
+func (fc *RSGF[E]) message(xs, ys []E) ([]E, error) {
+	// a codeword is determined by any k of its points
+	poly, err := utils.Interpolate(fc.f, xs[:fc.k], ys[:fc.k])
+	if err != nil {
+		return nil, err
+	}
+	for i, x := range xs {
+		if !fc.f.Equal(poly.Eval(x), ys[i]) {
+			return nil, tooManyErrors
+		}
+	}
+	return poly.Coefficients(fc.k), nil
+}
@@ -0,0 +1,93 @@
+package reedsolomonP
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"oec/field"
+)
+
+func TestSyndromeDecode(t *testing.T) {
+	p := big.NewInt(257)
+	rnd := rand.New(rand.NewSource(3))
+	for _, tc := range []struct{ k, n int }{{1, 3}, {3, 7}, {4, 13}, {8, 16}} {
+		fc, err := NewRSGFp(tc.k, tc.n, p, WithDecoder(SyndromeDecoder))
+		if err != nil {
+			t.Fatal(err)
+		}
+		input := make([]*big.Int, tc.k)
+		for i := range input {
+			input[i] = big.NewInt(rnd.Int63n(p.Int64()))
+		}
+		want, _ := fc.Encode(input)
+
+		for errs := 0; errs <= (tc.n-tc.k)/2; errs++ {
+			shares, _ := fc.Encode(input)
+			positions := rnd.Perm(tc.n)[:errs]
+			for _, i := range positions {
+				shares[i].Data = field.NewPrime(p).Add(shares[i].Data, big.NewInt(rnd.Int63n(256)+1))
+			}
+			got, errPos, err := fc.SyndromeDecode(shares)
+			if err != nil {
+				t.Fatalf("k=%d n=%d errors=%d: %v", tc.k, tc.n, errs, err)
+			}
+			if !sharesEqual(got, want) {
+				t.Errorf("k=%d n=%d errors=%d: expected %v, got %v", tc.k, tc.n, errs, want, got)
+			}
+			sort.Ints(positions)
+			sort.Ints(errPos)
+			if len(errPos) != len(positions) {
+				t.Fatalf("expected error positions %v, got %v", positions, errPos)
+			}
+			for i := range positions {
+				if positions[i] != errPos[i] {
+					t.Errorf("expected error positions %v, got %v", positions, errPos)
+				}
+			}
+		}
+	}
+}
+
+func TestSyndromeDecode_Subset(t *testing.T) {
+	p := big.NewInt(101)
+	fc, _ := NewRSGFp(3, 10, p, WithDecoder(SyndromeDecoder))
+	want, _ := fc.Encode([]*big.Int{big.NewInt(5), big.NewInt(6), big.NewInt(7)})
+
+	// shares 1, 4 and 8 are missing, share 6 is wrong
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(5), big.NewInt(6), big.NewInt(7)})
+	shares[6].Data = big.NewInt(0)
+	received := []Share{shares[0], shares[2], shares[3], shares[5], shares[6], shares[7], shares[9]}
+
+	got, err := fc.Correct(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sharesEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestParityCheckP(t *testing.T) {
+	p := big.NewInt(29)
+	fc, _ := NewRSGFp(3, 7, p)
+	shares, _ := fc.Encode([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)})
+
+	xs := make([]*big.Int, len(shares))
+	col := make(P, len(shares))
+	for i, share := range shares {
+		xs[i] = fc.point(share.Number)
+		col[i] = []*big.Int{share.Data}
+	}
+	h, err := ParityCheckP(xs, 3, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := h.Multiply(col, p)
+	for j := range s {
+		if s[j][0].Sign() != 0 {
+			t.Errorf("expected zero syndrome, got %v", s)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package reedsolomonP
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSystematic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(16))
+	const goldilocks = 0xffffffff00000001
+	tests := []struct {
+		name string
+		k, n int
+		opts []Option
+	}{
+		{"dense", 5, 12, []Option{WithDecoder(SyndromeDecoder)}},
+		{"NTT", 40, 64, []Option{WithDomain(RootsOfUnityDomain[uint64]())}},
+		{"SubproductTree", 260, 300, []Option{WithDecoder(GaoDecoder)}},
+	}
+	for _, test := range tests {
+		fc, err := NewRSGF64(test.k, test.n, goldilocks, append(test.opts, WithSystematic())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fc.Systematic() {
+			t.Fatalf("%s: not systematic", test.name)
+		}
+		f := fc.Field()
+		input := make([]uint64, test.k)
+		for i := range input {
+			input[i] = f.FromInt64(rnd.Int63())
+		}
+		shares, err := fc.Encode(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range input {
+			if shares[i].Data != input[i] {
+				t.Fatalf("%s: share %d is not the input", test.name, i)
+			}
+		}
+		if (fc.encMatrix != nil) != (test.name == "dense") {
+			t.Fatalf("%s: encoding matrix built for the wrong path", test.name)
+		}
+		encMatrix := denseMatrix(t, fc)
+		for i, share := range shares {
+			want := f.Zero()
+			for j := range input {
+				want = f.Add(want, f.Mul(input[j], encMatrix[i][j]))
+			}
+			if share.Data != want {
+				t.Fatalf("%s: share %d differs from the encoding matrix", test.name, i)
+			}
+		}
+
+		// data pieces alone, parity pieces alone and a mix of both
+		for _, numbers := range [][]int{rnd.Perm(test.k), rnd.Perm(test.n)[:test.k]} {
+			subset := make([]ShareOf[uint64], len(numbers))
+			for i, number := range numbers {
+				subset[i] = shares[number]
+			}
+			message := make([]uint64, test.k)
+			if err := fc.Rebuild(subset, func(s ShareOf[uint64]) { message[s.Number] = s.Data }); err != nil {
+				t.Fatal(err)
+			}
+			if !equalMessage(message, input) {
+				t.Fatalf("%s: rebuilt message differs", test.name)
+			}
+		}
+
+		received := append([]ShareOf[uint64]{}, shares...)
+		for _, i := range rnd.Perm(test.n)[:(test.n-test.k)/2] {
+			received[i].Data = f.Add(received[i].Data, f.One())
+		}
+		result, err := fc.Decode(received)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equalMessage(result.Message, input) {
+			t.Errorf("%s: decoded message is not the input", test.name)
+		}
+	}
+}
+
+func TestRebuild_MixedShares(t *testing.T) {
+	// shares below k are not the data pieces of a non-systematic code
+	fc, err := NewRSGF64(3, 6, 1<<61-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := fc.Field()
+	input := []uint64{f.FromInt64(7), f.FromInt64(11), f.FromInt64(13)}
+	shares, err := fc.Encode(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := make([]uint64, 3)
+	calls := 0
+	err = fc.Rebuild([]ShareOf[uint64]{shares[0], shares[2], shares[5]}, func(s ShareOf[uint64]) {
+		message[s.Number] = s.Data
+		calls++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || !equalMessage(message, input) {
+		t.Errorf("rebuilt %v with %d calls", message, calls)
+	}
+}
@@ -0,0 +1,21 @@
+package shamir
+
+import "errors"
+
+// errInvalidThreshold is returned if the threshold does not fit the number of parties.
+var errInvalidThreshold = errors.New("requires 0 <= t < n")
+
+// errInvalidPacking is returned if the number of packed secrets does not fit the parameters.
+var errInvalidPacking = errors.New("requires 1 <= l <= n-t")
+
+// errTooFewShares is returned if fewer than t+l shares are given to a reconstruction.
+var errTooFewShares = errors.New("too few shares given")
+
+// errDuplicateShare is returned if two shares have the same index.
+var errDuplicateShare = errors.New("duplicate share index")
+
+// errSchemeMismatch is returned if shares of different schemes are combined.
+var errSchemeMismatch = errors.New("shares belong to different schemes")
+
+// errInvalidIndex is returned if a share index is not in 1..n.
+var errInvalidIndex = errors.New("invalid share index")
@@ -0,0 +1,93 @@
+package shamir
+
+import (
+	"oec/field"
+	"oec/utils"
+)
+
+// NewPackedScheme returns the scheme of Franklin and Yung that shares l
+// secrets at once: they are the values of one random polynomial of degree
+// t+l-1 at the points 0, -1, ..., -(l-1), and party i receives its value at
+// i. Any t shares still reveal nothing, while t+l shares reconstruct all l
+// secrets, so a sharing costs one field element per party instead of l.
+func NewPackedScheme[E any](t, l, n int, f field.Field[E]) (*Scheme[E], error) {
+	return newScheme(t, l, n, f)
+}
+
+// Pack shares the l secrets among the n parties with fresh randomness.
+func (s *Scheme[E]) Pack(secrets []E) ([]Share[E], error) {
+	poly, err := s.RandomPoly(secrets...)
+	if err != nil {
+		return nil, err
+	}
+	return s.Shares(poly)
+}
+
+// Unpack interpolates the sharing polynomial from at least t+l shares,
+// trusting all of them, and returns the l secrets.
+func (s *Scheme[E]) Unpack(shares []Share[E]) ([]E, error) {
+	if err := s.check(shares); err != nil {
+		return nil, err
+	}
+	xs := make([]E, len(shares))
+	ys := make([]E, len(shares))
+	for i, sh := range shares {
+		xs[i] = s.Point(sh.Index)
+		ys[i] = sh.Value
+	}
+	poly, err := utils.Interpolate(s.f, xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	return s.secrets(poly), nil
+}
+
+// RobustUnpack decodes the shares before taking the secrets, so that up to
+// (m-t-l)/2 wrong shares among m are corrected. It also returns the indices
+// of the wrong shares.
+func (s *Scheme[E]) RobustUnpack(shares []Share[E]) ([]E, []int, error) {
+	poly, faulty, err := s.decode(shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.secrets(poly), faulty, nil
+}
+
+// secrets evaluates poly at the secret points.
+func (s *Scheme[E]) secrets(poly utils.PolyOf[E]) []E {
+	secrets := make([]E, len(s.secretXs))
+	for j, x := range s.secretXs {
+		secrets[j] = poly.Eval(x)
+	}
+	return secrets
+}
+
+// Pack shares the secrets with threshold t among n parties over f, packing
+// all of them into one polynomial of degree t+len(secrets)-1.
+func Pack[E any](secrets []E, t, n int, f field.Field[E]) ([]Share[E], error) {
+	s, err := NewPackedScheme(t, len(secrets), n, f)
+	if err != nil {
+		return nil, err
+	}
+	return s.Pack(secrets)
+}
+
+// Unpack returns the secrets from at least t+l shares created by the same
+// Pack.
+func Unpack[E any](shares []Share[E]) ([]E, error) {
+	if len(shares) == 0 || shares[0].scheme == nil {
+		return nil, errTooFewShares
+	}
+	return shares[0].scheme.Unpack(shares)
+}
+
+// RobustUnpack returns the secrets from shares created by the same Pack
+// while tolerating Byzantine shares: with m shares, up to (m-t-l)/2 of them
+// may be wrong.
+func RobustUnpack[E any](shares []Share[E]) ([]E, error) {
+	if len(shares) == 0 || shares[0].scheme == nil {
+		return nil, errTooFewShares
+	}
+	secrets, _, err := shares[0].scheme.RobustUnpack(shares)
+	return secrets, err
+}
@@ -0,0 +1,84 @@
+package shamir
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"oec/field"
+)
+
+func TestPackUnpack(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	tt, l, n := 3, 4, 16
+	secrets := make([]uint64, l)
+	for j := range secrets {
+		secrets[j] = f.FromInt64(int64(100 + j))
+	}
+	shares, err := Pack[uint64](secrets, tt, n, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := shares[0].Scheme()
+	if s.Packing() != l || s.Degree() != tt+l-1 {
+		t.Fatalf("packing %d, degree %d", s.Packing(), s.Degree())
+	}
+
+	rnd := rand.New(rand.NewSource(18))
+	subset := make([]Share[uint64], tt+l)
+	for i, j := range rnd.Perm(n)[:tt+l] {
+		subset[i] = shares[j]
+	}
+	got, err := Unpack(subset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := range secrets {
+		if got[j] != secrets[j] {
+			t.Fatalf("secret %d: got %d", j, f.ToUint64(got[j]))
+		}
+	}
+	if first, err := Reconstruct(subset); err != nil || first != secrets[0] {
+		t.Errorf("Reconstruct returned the wrong first secret")
+	}
+	if _, err := Unpack(subset[:tt+l-1]); err == nil {
+		t.Errorf("unpacked from t+l-1 shares")
+	}
+
+	// (n-t-l)/2 Byzantine parties
+	for _, i := range rnd.Perm(n)[:(n-tt-l)/2] {
+		shares[i].Value = f.Add(shares[i].Value, f.One())
+	}
+	got, faulty, err := s.RobustUnpack(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(faulty) != (n-tt-l)/2 {
+		t.Errorf("found %d wrong shares", len(faulty))
+	}
+	for j := range secrets {
+		if got[j] != secrets[j] {
+			t.Fatalf("robust secret %d: got %d", j, f.ToUint64(got[j]))
+		}
+	}
+	if _, err := s.Split(secrets[0]); err == nil {
+		t.Errorf("split a single secret with a packed scheme")
+	}
+}
+
+func TestNewPackedScheme(t *testing.T) {
+	f := field.NewPrime(big.NewInt(11))
+	if _, err := NewPackedScheme[*big.Int](2, 0, 7, f); err == nil {
+		t.Errorf("accepted l = 0")
+	}
+	if _, err := NewPackedScheme[*big.Int](2, 6, 7, f); err == nil {
+		t.Errorf("accepted t+l > n")
+	}
+	// the secret point -3 = 8 is the point of party 8
+	if _, err := NewPackedScheme[*big.Int](2, 4, 8, f); err == nil {
+		t.Errorf("accepted n+l > p")
+	}
+	if _, err := NewPackedScheme[*big.Int](2, 4, 7, f); err != nil {
+		t.Error(err)
+	}
+}
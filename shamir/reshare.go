@@ -0,0 +1,173 @@
+package shamir
+
+import (
+	"errors"
+	"fmt"
+
+	"oec/field"
+)
+
+// ZeroSharing returns fresh shares of zero for all n parties. In a refresh
+// every holder deals one and sends share i to party i.
+func (s *Scheme[E]) ZeroSharing() ([]Share[E], error) {
+	return s.Split(s.f.Zero())
+}
+
+// Refresh adds the shares of zero dealt to the party of share by the other
+// holders. The refreshed shares are a fresh random sharing of the same
+// secret, so shares leaked before the refresh are useless together with
+// shares leaked after it. A dealer of a sharing of a nonzero value shifts the
+// secret; Reshare to the same scheme refreshes with an OEC check instead.
+func Refresh[E any](share Share[E], zeros []Share[E]) (Share[E], error) {
+	s := share.scheme
+	if s == nil {
+		return Share[E]{}, errSchemeMismatch
+	}
+	refreshed := Share[E]{Index: share.Index, Value: share.Value, scheme: s}
+	for _, z := range zeros {
+		if z.scheme != s {
+			return Share[E]{}, errSchemeMismatch
+		}
+		if z.Index != share.Index {
+			return Share[E]{}, fmt.Errorf("share of zero for party %d given to party %d", z.Index, share.Index)
+		}
+		refreshed.Value = s.f.Add(refreshed.Value, z.Value)
+	}
+	return refreshed, nil
+}
+
+// SubShare is the value old party From sends to new party To in a resharing.
+type SubShare[E any] struct {
+	From  int
+	To    int
+	Value E
+}
+
+// MaskSharings returns the t' random sharings an old party of s deals for a
+// resharing to the scheme to: masks[k] holds the shares of all n parties of
+// the k+1-th one.
+func (s *Scheme[E]) MaskSharings(to *Scheme[E]) ([][]Share[E], error) {
+	if err := s.compatible(to); err != nil {
+		return nil, err
+	}
+	masks := make([][]Share[E], to.Degree())
+	for k := range masks {
+		r, err := s.f.Rand(nil)
+		if err != nil {
+			return nil, err
+		}
+		if masks[k], err = s.Split(r); err != nil {
+			return nil, err
+		}
+	}
+	return masks, nil
+}
+
+// CombineMasks adds the mask shares one party received from the dealers,
+// received[d][k] being the share of the k-th sharing from dealer d. It
+// returns the party's shares of the t' mask coefficients.
+func CombineMasks[E any](received [][]Share[E]) ([]Share[E], error) {
+	if len(received) == 0 {
+		return nil, errors.New("no mask shares given")
+	}
+	mask := append([]Share[E]{}, received[0]...)
+	for _, shares := range received[1:] {
+		if len(shares) != len(mask) {
+			return nil, errors.New("dealers sent different numbers of mask shares")
+		}
+		for k, sh := range shares {
+			if sh.scheme != mask[k].scheme || sh.scheme == nil {
+				return nil, errSchemeMismatch
+			}
+			if sh.Index != mask[k].Index {
+				return nil, fmt.Errorf("mask share for party %d given to party %d", sh.Index, mask[k].Index)
+			}
+			mask[k].Value = sh.scheme.f.Add(mask[k].Value, sh.Value)
+		}
+	}
+	return mask, nil
+}
+
+// SubShares returns the sub-shares old party share.Index sends to the n'
+// parties of to, given its shares of the mask coefficients.
+func (s *Scheme[E]) SubShares(share Share[E], mask []Share[E], to *Scheme[E]) ([]SubShare[E], error) {
+	if err := s.compatible(to); err != nil {
+		return nil, err
+	}
+	if share.scheme != s {
+		return nil, errSchemeMismatch
+	}
+	if len(mask) != to.Degree() {
+		return nil, fmt.Errorf("resharing to degree %d needs %d mask shares, got %d", to.Degree(), to.Degree(), len(mask))
+	}
+	for _, m := range mask {
+		if m.scheme != s || m.Index != share.Index {
+			return nil, errSchemeMismatch
+		}
+	}
+	f := s.f
+	out := make([]SubShare[E], to.n)
+	for j := range out {
+		// f(i) + sum_k R_k(i) x^k by Horner's rule in x
+		x := to.Point(j + 1)
+		v := f.Zero()
+		for k := len(mask) - 1; k >= 0; k-- {
+			v = f.Mul(f.Add(v, mask[k].Value), x)
+		}
+		out[j] = SubShare[E]{From: share.Index, To: j + 1, Value: f.Add(share.Value, v)}
+	}
+	return out, nil
+}
+
+// Reshare returns the share of party index of the scheme to, decoded from
+// the sub-shares it received from the old parties of s. With m sub-shares,
+// up to (m-t-1)/2 of them may be wrong; the old parties that sent those are
+// returned as well.
+//
+// A resharing from s with threshold t to a scheme with threshold t' moves
+// the secret f(0) onto h(y) = f(0) + r_1 y + ... + r_t' y^t' for random r_k
+// that no t old parties know:
+//
+//  1. Every old party deals MaskSharings, t' random sharings in s. Party i
+//     adds the shares it receives with CombineMasks and so holds shares
+//     R_k(i) of r_k = R_k(0).
+//  2. Old party i sends v_ij = f(i) + sum_k R_k(i) x_j^k, with x_j the point
+//     of new party j, as computed by SubShares.
+//  3. For fixed j, v_ij is the value at i of f(x) + sum_k R_k(x) x_j^k, a
+//     polynomial of degree <= t whose value at 0 is h(x_j). New party j
+//     decodes it from the sub-shares with Reshare, which corrects wrong
+//     sub-shares from corrupted old parties.
+//
+// Reshare to s itself refreshes the sharing.
+func (s *Scheme[E]) Reshare(to *Scheme[E], index int, subShares []SubShare[E]) (Share[E], []int, error) {
+	if err := s.compatible(to); err != nil {
+		return Share[E]{}, nil, err
+	}
+	if index < 1 || index > to.n {
+		return Share[E]{}, nil, errInvalidIndex
+	}
+	shares := make([]Share[E], len(subShares))
+	for i, sub := range subShares {
+		if sub.To != index {
+			return Share[E]{}, nil, fmt.Errorf("sub-share for party %d given to party %d", sub.To, index)
+		}
+		shares[i] = Share[E]{Index: sub.From, Value: sub.Value, scheme: s}
+	}
+	value, faulty, err := s.RobustReconstruct(shares)
+	if err != nil {
+		return Share[E]{}, nil, err
+	}
+	return Share[E]{Index: index, Value: value, scheme: to}, faulty, nil
+}
+
+// compatible checks that a resharing from s to the scheme to is possible:
+// both share single secrets over the same field.
+func (s *Scheme[E]) compatible(to *Scheme[E]) error {
+	if to == nil || !field.Same(s.f, to.f) {
+		return errors.New("schemes are over different fields")
+	}
+	if s.Packing() != 1 || to.Packing() != 1 {
+		return errors.New("cannot reshare packed secrets")
+	}
+	return nil
+}
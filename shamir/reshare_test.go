@@ -0,0 +1,114 @@
+package shamir
+
+import (
+	"testing"
+
+	"oec/field"
+)
+
+func TestRefresh(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	s, _ := NewScheme[uint64](2, 7, f)
+	secret := f.FromInt64(99)
+	shares, err := s.Split(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zeros := make([][]Share[uint64], s.Parties())
+	for d := range zeros {
+		if zeros[d], err = s.ZeroSharing(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	refreshed := make([]Share[uint64], len(shares))
+	for i, share := range shares {
+		received := make([]Share[uint64], len(zeros))
+		for d := range zeros {
+			received[d] = zeros[d][i]
+		}
+		if refreshed[i], err = Refresh(share, received); err != nil {
+			t.Fatal(err)
+		}
+		if refreshed[i].Value == share.Value {
+			t.Errorf("share %d did not change", share.Index)
+		}
+	}
+	got, faulty, err := s.RobustReconstruct(refreshed)
+	if err != nil || got != secret || len(faulty) != 0 {
+		t.Errorf("refreshed sharing: %v, faulty %v", err, faulty)
+	}
+	if _, err := Refresh(shares[0], zeros[0][1:2]); err == nil {
+		t.Errorf("added a share of zero of another party")
+	}
+}
+
+func TestReshare(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	from, _ := NewScheme[uint64](2, 7, f)
+	to, _ := NewScheme[uint64](3, 10, f)
+	secret := f.FromInt64(2024)
+	shares, err := from.Split(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	masks := make([][][]Share[uint64], from.Parties())
+	for d := range masks {
+		if masks[d], err = from.MaskSharings(to); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sent := make([][]SubShare[uint64], from.Parties())
+	for i, share := range shares {
+		received := make([][]Share[uint64], len(masks))
+		for d := range masks {
+			received[d] = make([]Share[uint64], to.Degree())
+			for k := range received[d] {
+				received[d][k] = masks[d][k][i]
+			}
+		}
+		mask, err := CombineMasks(received)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent[i], err = from.SubShares(share, mask, to); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// old party 3 is corrupted and sends garbage
+	for j := range sent[2] {
+		sent[2][j].Value = f.FromInt64(int64(j))
+	}
+
+	reshared := make([]Share[uint64], to.Parties())
+	for j := range reshared {
+		received := make([]SubShare[uint64], len(sent))
+		for i := range sent {
+			received[i] = sent[i][j]
+		}
+		var faulty []int
+		if reshared[j], faulty, err = from.Reshare(to, j+1, received); err != nil {
+			t.Fatal(err)
+		}
+		if len(faulty) != 1 || faulty[0] != 3 {
+			t.Errorf("new party %d found faulty old parties %v", j+1, faulty)
+		}
+	}
+	got, faulty, err := to.RobustReconstruct(reshared)
+	if err != nil || got != secret || len(faulty) != 0 {
+		t.Fatalf("reshared sharing: %v, faulty %v", err, faulty)
+	}
+	if got, _ := to.Reconstruct(reshared[5:9]); got != secret {
+		t.Errorf("t'+1 new shares do not reconstruct the secret")
+	}
+
+	// GF(2^4) modulo x^4+x+1 and modulo x^4+x^3+1 have the same order but
+	// encode their elements differently
+	f13, _ := field.NewGF2m(4, 0x13)
+	f19, _ := field.NewGF2m(4, 0x19)
+	from2, _ := NewScheme[uint16](1, 5, f13)
+	to2, _ := NewScheme[uint16](1, 5, f19)
+	if _, err := from2.MaskSharings(to2); err == nil {
+		t.Errorf("resharing between different fields of the same order accepted")
+	}
+}
@@ -0,0 +1,281 @@
+// Package shamir implements Shamir secret sharing on top of the codes of
+// package reedsolomonP. A secret s is shared with threshold t by a random
+// polynomial f of degree <= t with f(0) = s; party i in 1..n receives f(i).
+// Any t+1 shares determine the secret, t shares reveal nothing about it.
+//
+// The shares of all n parties form a codeword of the Reed-Solomon code with
+// k = t+1, so a robust reconstruction is a decoding of that code.
+package shamir
+
+import (
+	"fmt"
+
+	"oec/field"
+	"oec/reedsolomonP"
+	"oec/utils"
+)
+
+// Scheme fixes the field, the threshold t and the number of parties n. A
+// packed scheme, see NewPackedScheme, shares l secrets at once with one
+// polynomial of degree t+l-1; plain Shamir sharing is the case l = 1.
+type Scheme[E any] struct {
+	t        int
+	n        int
+	f        field.Field[E]
+	xs       []E
+	secretXs []E // the points holding the secrets, 0, -1, ..., -(l-1)
+	code     *reedsolomonP.RSGF[E]
+}
+
+// Share is the value of a sharing polynomial at the point of party Index.
+type Share[E any] struct {
+	Index  int
+	Value  E
+	scheme *Scheme[E]
+}
+
+// NewScheme returns the scheme for n parties and threshold t over f. Party i
+// evaluates at the field element encoded by i, so these must be nonzero and
+// distinct for i = 1..n.
+func NewScheme[E any](t, n int, f field.Field[E]) (*Scheme[E], error) {
+	return newScheme(t, 1, n, f)
+}
+
+// newScheme returns the scheme sharing l secrets at the points 0, -1, ...,
+// -(l-1) with threshold t among n parties.
+func newScheme[E any](t, l, n int, f field.Field[E]) (*Scheme[E], error) {
+	if t < 0 || t >= n {
+		return nil, errInvalidThreshold
+	}
+	if l < 1 || t+l > n {
+		return nil, errInvalidPacking
+	}
+	code, err := reedsolomonP.NewRSGF[E](t+l, n, f, reedsolomonP.WithDomain(reedsolomonP.ConsecutiveDomain[E](1)))
+	if err != nil {
+		return nil, err
+	}
+	xs := code.Points()
+	secretXs := make([]E, l)
+	for j := range secretXs {
+		secretXs[j] = f.FromInt64(-int64(j))
+	}
+	seen := make(map[string]bool, n+l)
+	for _, x := range append(append([]E{}, secretXs...), xs...) {
+		key := f.ToBig(x).String()
+		if seen[key] {
+			return nil, fmt.Errorf("%d parties and %d secrets need %d distinct points", n, l, n+l)
+		}
+		seen[key] = true
+	}
+	return &Scheme[E]{t: t, n: n, f: f, xs: xs, secretXs: secretXs, code: code}, nil
+}
+
+// Threshold returns t: any t shares reveal nothing about the secrets.
+func (s *Scheme[E]) Threshold() int {
+	return s.t
+}
+
+// Packing returns the number l of secrets shared by one polynomial.
+func (s *Scheme[E]) Packing() int {
+	return len(s.secretXs)
+}
+
+// Degree returns the degree bound t+l-1 of the sharing polynomials:
+// Degree()+1 shares are needed to reconstruct.
+func (s *Scheme[E]) Degree() int {
+	return s.t + len(s.secretXs) - 1
+}
+
+// Parties returns n.
+func (s *Scheme[E]) Parties() int {
+	return s.n
+}
+
+// Field returns the field the secrets live in.
+func (s *Scheme[E]) Field() field.Field[E] {
+	return s.f
+}
+
+// Point returns the evaluation point of party index.
+func (s *Scheme[E]) Point(index int) E {
+	return s.xs[index-1]
+}
+
+// Code returns the Reed-Solomon code whose codewords are the sharings.
+func (s *Scheme[E]) Code() *reedsolomonP.RSGF[E] {
+	return s.code
+}
+
+// NewShare returns the share of party index with the given value, for
+// instance one received from that party.
+func (s *Scheme[E]) NewShare(index int, value E) (Share[E], error) {
+	if index < 1 || index > s.n {
+		return Share[E]{}, errInvalidIndex
+	}
+	return Share[E]{Index: index, Value: value, scheme: s}, nil
+}
+
+// Scheme returns the scheme the share belongs to.
+func (sh Share[E]) Scheme() *Scheme[E] {
+	return sh.scheme
+}
+
+// RandomPoly returns a uniformly random polynomial of degree <= t+l-1 whose
+// value at the j-th secret point is secrets[j]. For l = 1 that is a
+// polynomial of degree <= t with constant term secrets[0].
+func (s *Scheme[E]) RandomPoly(secrets ...E) (utils.PolyOf[E], error) {
+	l := len(s.secretXs)
+	if len(secrets) != l {
+		return utils.PolyOf[E]{}, fmt.Errorf("scheme packs %d secrets, got %d", l, len(secrets))
+	}
+	random := make([]E, s.t)
+	for i := range random {
+		c, err := s.f.Rand(nil)
+		if err != nil {
+			return utils.PolyOf[E]{}, err
+		}
+		random[i] = c
+	}
+	if l == 1 {
+		return utils.NewPolyOf(s.f, append([]E{secrets[0]}, random...)...), nil
+	}
+	// random values at the points of the first t parties fix the rest
+	xs := append(append([]E{}, s.secretXs...), s.xs[:s.t]...)
+	return utils.Interpolate(s.f, xs, append(append([]E{}, secrets...), random...))
+}
+
+// Shares returns the shares of all n parties for the sharing polynomial
+// poly, which must have degree <= t+l-1.
+func (s *Scheme[E]) Shares(poly utils.PolyOf[E]) ([]Share[E], error) {
+	if poly.Degree() > s.Degree() {
+		return nil, fmt.Errorf("sharing polynomial has degree %d > %d", poly.Degree(), s.Degree())
+	}
+	codeword, err := s.code.Encode(poly.Coefficients(s.Degree() + 1))
+	if err != nil {
+		return nil, err
+	}
+	shares := make([]Share[E], s.n)
+	for i, c := range codeword {
+		shares[i] = Share[E]{Index: c.Number + 1, Value: c.Data, scheme: s}
+	}
+	return shares, nil
+}
+
+// Split shares secret among the n parties with fresh randomness. A packed
+// scheme shares its secrets with Pack instead.
+func (s *Scheme[E]) Split(secret E) ([]Share[E], error) {
+	poly, err := s.RandomPoly(secret)
+	if err != nil {
+		return nil, err
+	}
+	return s.Shares(poly)
+}
+
+// Reconstruct interpolates the secret from at least t+1 shares, trusting all
+// of them. For a packed scheme it needs t+l shares and returns the first
+// secret.
+func (s *Scheme[E]) Reconstruct(shares []Share[E]) (E, error) {
+	var zero E
+	if err := s.check(shares); err != nil {
+		return zero, err
+	}
+	xs := make([]E, len(shares))
+	for i, sh := range shares {
+		xs[i] = s.Point(sh.Index)
+	}
+	l, err := utils.LagrangeCoefficients(s.f, xs, s.secretXs[0])
+	if err != nil {
+		return zero, err
+	}
+	secret := s.f.Zero()
+	for i, sh := range shares {
+		secret = s.f.Add(secret, s.f.Mul(sh.Value, l[i]))
+	}
+	return secret, nil
+}
+
+// RobustReconstruct decodes the shares before taking the secret, so that up
+// to (m-t-1)/2 wrong shares among m are corrected. It also returns the
+// indices of the wrong shares. For a packed scheme it corrects (m-t-l)/2
+// wrong shares and returns the first secret.
+func (s *Scheme[E]) RobustReconstruct(shares []Share[E]) (E, []int, error) {
+	var zero E
+	secrets, faulty, err := s.RobustUnpack(shares)
+	if err != nil {
+		return zero, nil, err
+	}
+	return secrets[0], faulty, nil
+}
+
+// decode runs the decoder of the code on the shares and returns the sharing
+// polynomial and the indices of the wrong shares.
+func (s *Scheme[E]) decode(shares []Share[E]) (utils.PolyOf[E], []int, error) {
+	if err := s.check(shares); err != nil {
+		return utils.PolyOf[E]{}, nil, err
+	}
+	received := make([]reedsolomonP.ShareOf[E], len(shares))
+	for i, sh := range shares {
+		received[i] = reedsolomonP.ShareOf[E]{Number: sh.Index - 1, Data: sh.Value}
+	}
+	result, err := s.code.Decode(received)
+	if err != nil {
+		return utils.PolyOf[E]{}, nil, err
+	}
+	faulty := make([]int, len(result.Faulty))
+	for i, number := range result.Faulty {
+		faulty[i] = number + 1
+	}
+	return utils.NewPolyOf(s.f, result.Message...), faulty, nil
+}
+
+// check verifies that there are at least t+l distinct shares of s.
+func (s *Scheme[E]) check(shares []Share[E]) error {
+	if len(shares) < s.Degree()+1 {
+		return errTooFewShares
+	}
+	seen := make(map[int]bool, len(shares))
+	for _, sh := range shares {
+		if sh.scheme != s {
+			return errSchemeMismatch
+		}
+		if sh.Index < 1 || sh.Index > s.n {
+			return errInvalidIndex
+		}
+		if seen[sh.Index] {
+			return errDuplicateShare
+		}
+		seen[sh.Index] = true
+	}
+	return nil
+}
+
+// Split shares secret with threshold t among n parties over f.
+func Split[E any](secret E, t, n int, f field.Field[E]) ([]Share[E], error) {
+	s, err := NewScheme(t, n, f)
+	if err != nil {
+		return nil, err
+	}
+	return s.Split(secret)
+}
+
+// Reconstruct interpolates the secret at zero from at least t+1 shares
+// created by the same Split.
+func Reconstruct[E any](shares []Share[E]) (E, error) {
+	var zero E
+	if len(shares) == 0 || shares[0].scheme == nil {
+		return zero, errTooFewShares
+	}
+	return shares[0].scheme.Reconstruct(shares)
+}
+
+// RobustReconstruct reconstructs the secret from shares created by the same
+// Split while tolerating Byzantine shares: with m shares, up to (m-t-1)/2 of
+// them may be wrong, for instance t out of n = 3t+1.
+func RobustReconstruct[E any](shares []Share[E]) (E, error) {
+	var zero E
+	if len(shares) == 0 || shares[0].scheme == nil {
+		return zero, errTooFewShares
+	}
+	secret, _, err := shares[0].scheme.RobustReconstruct(shares)
+	return secret, err
+}
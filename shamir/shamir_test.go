@@ -0,0 +1,111 @@
+package shamir
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"oec/field"
+)
+
+func TestSplitReconstruct(t *testing.T) {
+	f := field.NewPrime(big.NewInt(2147483647))
+	secret := big.NewInt(123456789)
+	shares, err := Split[*big.Int](secret, 2, 7, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 7 {
+		t.Fatalf("got %d shares", len(shares))
+	}
+	for i, sh := range shares {
+		if sh.Index != i+1 {
+			t.Fatalf("share %d has index %d", i, sh.Index)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(17))
+	for m := 3; m <= 7; m++ {
+		subset := make([]Share[*big.Int], m)
+		for i, j := range rnd.Perm(7)[:m] {
+			subset[i] = shares[j]
+		}
+		got, err := Reconstruct(subset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Cmp(secret) != 0 {
+			t.Errorf("%d shares: reconstructed %v", m, got)
+		}
+	}
+
+	if _, err := Reconstruct(shares[:2]); err == nil {
+		t.Errorf("reconstructed from t shares")
+	}
+	if _, err := Reconstruct([]Share[*big.Int]{shares[0], shares[1], shares[1]}); err == nil {
+		t.Errorf("accepted a duplicate share")
+	}
+	other, _ := Split[*big.Int](secret, 2, 7, f)
+	if _, err := Reconstruct([]Share[*big.Int]{shares[0], shares[1], other[2]}); err == nil {
+		t.Errorf("mixed shares of two sharings")
+	}
+}
+
+func TestRobustReconstruct(t *testing.T) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	tt := 3
+	n := 3*tt + 1
+	s, err := NewScheme[uint64](tt, n, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := f.FromInt64(42)
+	shares, err := s.Split(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// t Byzantine parties
+	for _, i := range []int{0, 4, 8} {
+		shares[i].Value = f.Add(shares[i].Value, f.One())
+	}
+	got, faulty, err := s.RobustReconstruct(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != secret {
+		t.Errorf("reconstructed %d", f.ToUint64(got))
+	}
+	if len(faulty) != 3 || faulty[0] != 1 || faulty[1] != 5 || faulty[2] != 9 {
+		t.Errorf("faulty shares %v", faulty)
+	}
+	if got, err := RobustReconstruct(shares); err != nil || got != secret {
+		t.Errorf("RobustReconstruct: %v", err)
+	}
+	if got, _ := Reconstruct(shares); got == secret {
+		t.Errorf("plain reconstruction ignored the wrong shares")
+	}
+
+	shares[1].Value = f.Add(shares[1].Value, f.One())
+	if _, _, err := s.RobustReconstruct(shares); err == nil {
+		t.Errorf("decoded with t+1 wrong shares")
+	}
+}
+
+func TestNewScheme(t *testing.T) {
+	f := field.NewPrime(big.NewInt(11))
+	if _, err := NewScheme[*big.Int](3, 3, f); err == nil {
+		t.Errorf("accepted t = n")
+	}
+	// party 11 would evaluate at zero
+	if _, err := NewScheme[*big.Int](2, 11, f); err == nil {
+		t.Errorf("accepted n = p")
+	}
+	s, err := NewScheme[*big.Int](2, 10, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.NewShare(11, f.One()); err == nil {
+		t.Errorf("accepted index 11")
+	}
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+
+	"oec/field"
+)
+
+// BiPolyOf is a bivariate polynomial over the field F,
+// BiPolyOf(x, y) = sum_{i,j} Coeff[i][j] x^i y^j, of degree < len(Coeff) in x
+// and < len(Coeff[i]) in y. It is symmetric if Coeff[i][j] = Coeff[j][i], in
+// which case F(x, y) = F(y, x) and the rows equal the columns.
+//
+// In bivariate sharing party i receives the row F(x_i, y) and the column
+// F(x, x_i); the secret F(0, 0) is shared by the univariate polynomial
+// F(x, 0), of which party i's share is its row at 0.
+type BiPolyOf[E any] struct {
+	F     field.Field[E]
+	Coeff [][]E
+}
+
+// BiPoly is a bivariate polynomial over GF(p).
+type BiPoly = BiPolyOf[*big.Int]
+
+// NewBiPolyOf returns the bivariate polynomial with the given coefficients,
+// coeff[i][j] being the coefficient of x^i y^j. All rows must have the same
+// length.
+func NewBiPolyOf[E any](f field.Field[E], coeff [][]E) (BiPolyOf[E], error) {
+	for _, row := range coeff {
+		if len(row) != len(coeff[0]) {
+			return BiPolyOf[E]{}, errors.New("coefficient rows have different lengths")
+		}
+	}
+	return BiPolyOf[E]{F: f, Coeff: coeff}, nil
+}
+
+// NewRandBiPolyOf returns a uniformly random bivariate polynomial of degree
+// <= dx in x and <= dy in y with F(0, 0) = secret.
+func NewRandBiPolyOf[E any](f field.Field[E], dx, dy int, secret E) (BiPolyOf[E], error) {
+	if dx < 0 || dy < 0 {
+		return BiPolyOf[E]{}, errors.New("degree must be non-negative")
+	}
+	coeff := make([][]E, dx+1)
+	for i := range coeff {
+		coeff[i] = make([]E, dy+1)
+		for j := range coeff[i] {
+			c, err := f.Rand(nil)
+			if err != nil {
+				return BiPolyOf[E]{}, err
+			}
+			coeff[i][j] = c
+		}
+	}
+	coeff[0][0] = secret
+	return BiPolyOf[E]{F: f, Coeff: coeff}, nil
+}
+
+// NewRandSymmetricBiPolyOf returns a uniformly random symmetric bivariate
+// polynomial of degree <= d in each variable with F(0, 0) = secret.
+func NewRandSymmetricBiPolyOf[E any](f field.Field[E], d int, secret E) (BiPolyOf[E], error) {
+	b, err := NewRandBiPolyOf(f, d, d, secret)
+	if err != nil {
+		return BiPolyOf[E]{}, err
+	}
+	for i := range b.Coeff {
+		for j := 0; j < i; j++ {
+			b.Coeff[i][j] = b.Coeff[j][i]
+		}
+	}
+	return b, nil
+}
+
+// NewRandBiPoly returns a random bivariate polynomial over GF(p) of degree
+// <= dx in x and <= dy in y with F(0, 0) = secret.
+func NewRandBiPoly(dx, dy int, p, secret *big.Int) (BiPoly, error) {
+	f := field.NewPrime(p)
+	return NewRandBiPolyOf[*big.Int](f, dx, dy, f.FromBig(secret))
+}
+
+// DegreeX returns the degree bound in x.
+func (b BiPolyOf[E]) DegreeX() int {
+	return len(b.Coeff) - 1
+}
+
+// DegreeY returns the degree bound in y.
+func (b BiPolyOf[E]) DegreeY() int {
+	if len(b.Coeff) == 0 {
+		return -1
+	}
+	return len(b.Coeff[0]) - 1
+}
+
+// IsSymmetric reports whether F(x, y) = F(y, x).
+func (b BiPolyOf[E]) IsSymmetric() bool {
+	if b.DegreeX() != b.DegreeY() {
+		return false
+	}
+	for i := range b.Coeff {
+		for j := 0; j < i; j++ {
+			if !b.F.Equal(b.Coeff[i][j], b.Coeff[j][i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Eval returns F(x, y).
+func (b BiPolyOf[E]) Eval(x, y E) E {
+	return b.Row(x).Eval(y)
+}
+
+// Row returns the polynomial F(x0, y) in y.
+func (b BiPolyOf[E]) Row(x0 E) PolyOf[E] {
+	f := b.F
+	coeff := make([]E, b.DegreeY()+1)
+	for j := range coeff {
+		// sum_i Coeff[i][j] x0^i by Horner's rule
+		c := f.Zero()
+		for i := len(b.Coeff) - 1; i >= 0; i-- {
+			c = f.Add(f.Mul(c, x0), b.Coeff[i][j])
+		}
+		coeff[j] = c
+	}
+	return NewPolyOf(f, coeff...)
+}
+
+// Column returns the polynomial F(x, y0) in x.
+func (b BiPolyOf[E]) Column(y0 E) PolyOf[E] {
+	f := b.F
+	coeff := make([]E, len(b.Coeff))
+	for i, row := range b.Coeff {
+		coeff[i] = NewPolyOf(f, row...).Eval(y0)
+	}
+	return NewPolyOf(f, coeff...)
+}
+
+// ConsistentPoint reports whether the row F(xi, y) held by party i and the
+// column F(x, xj) held by party j agree on their common point F(xi, xj). For
+// a symmetric polynomial the column of party j is its row.
+func ConsistentPoint[E any](row PolyOf[E], xi E, column PolyOf[E], xj E) bool {
+	return row.F.Equal(row.Eval(xj), column.Eval(xi))
+}
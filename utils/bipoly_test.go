@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"oec/field"
+)
+
+func TestBiPolyOf(t *testing.T) {
+	f := field.NewPrime(big.NewInt(1000003))
+	secret := f.FromInt64(42)
+	b, err := NewRandBiPolyOf[*big.Int](f, 2, 4, secret)
+	assert.Nil(t, err, "NewRandBiPolyOf")
+	assert.Equal(t, 2, b.DegreeX())
+	assert.Equal(t, 4, b.DegreeY())
+	assert.Equal(t, secret, b.Eval(f.Zero(), f.Zero()), "F(0, 0)")
+	assert.False(t, b.IsSymmetric(), "asymmetric")
+
+	xs := []*big.Int{f.FromInt64(1), f.FromInt64(2), f.FromInt64(3), f.FromInt64(4)}
+	for _, xi := range xs {
+		row, column := b.Row(xi), b.Column(xi)
+		assert.True(t, row.Degree() <= 4 && column.Degree() <= 2, "row and column degrees")
+		for _, xj := range xs {
+			assert.Equal(t, b.Eval(xi, xj), row.Eval(xj), "row")
+			assert.Equal(t, b.Eval(xj, xi), column.Eval(xj), "column")
+			assert.True(t, ConsistentPoint(row, xi, b.Column(xj), xj), "rows and columns agree")
+		}
+	}
+	wrong := b.Row(xs[0]).Add(NewPolyOf[*big.Int](f, f.One()))
+	assert.False(t, ConsistentPoint(wrong, xs[0], b.Column(xs[1]), xs[1]), "wrong row")
+
+	s, err := NewRandSymmetricBiPolyOf[*big.Int](f, 3, secret)
+	assert.Nil(t, err, "NewRandSymmetricBiPolyOf")
+	assert.True(t, s.IsSymmetric(), "symmetric")
+	for _, xi := range xs {
+		assert.True(t, s.Row(xi).Equal(s.Column(xi)), "row equals column")
+		assert.True(t, ConsistentPoint(s.Row(xi), xi, s.Row(xs[2]), xs[2]), "symmetric rows agree")
+	}
+
+	_, err = NewBiPolyOf[*big.Int](f, [][]*big.Int{{f.One()}, {f.One(), f.One()}})
+	assert.NotNil(t, err, "ragged coefficients")
+	g, err := NewRandBiPoly(1, 1, big.NewInt(101), big.NewInt(205))
+	assert.Nil(t, err, "NewRandBiPoly")
+	assert.Equal(t, int64(3), g.Eval(g.F.Zero(), g.F.Zero()).Int64(), "secret mod p")
+}
@@ -0,0 +1,118 @@
+package utils
+
+import "oec/field"
+
+// newtonThreshold is the degree of the divisor and of the quotient from which
+// DivMod switches from long division to Newton iteration.
+const newtonThreshold = 512
+
+// LongDivMod returns q, r such that a = b*q + r and deg r < deg b by
+// schoolbook long division, reducing a copy of a in place. It takes
+// O(deg b * deg q) operations.
+func (a PolyOf[E]) LongDivMod(b PolyOf[E]) (PolyOf[E], PolyOf[E], error) {
+	f := a.F
+	b = b.trim()
+	d := len(b.Coeff) - 1
+	if d < 0 {
+		return PolyOf[E]{}, PolyOf[E]{}, errDivideByZero
+	}
+	lcInv, err := f.Inv(b.Coeff[d])
+	if err != nil {
+		return PolyOf[E]{}, PolyOf[E]{}, err
+	}
+
+	r := append([]E{}, a.trim().Coeff...)
+	if len(r) <= d {
+		return PolyOf[E]{F: f}, NewPolyOf(f, r...), nil
+	}
+	q := make([]E, len(r)-d)
+	for i := len(q) - 1; i >= 0; i-- {
+		c := f.Mul(r[i+d], lcInv)
+		q[i] = c
+		for j := 0; j <= d; j++ {
+			r[i+j] = f.Sub(r[i+j], f.Mul(c, b.Coeff[j]))
+		}
+	}
+	return NewPolyOf(f, q...), NewPolyOf(f, r[:d]...), nil
+}
+
+// FastDivMod returns q, r such that a = b*q + r and deg r < deg b. With
+// n = deg a, d = deg b and rev(p) the coefficients of p in reverse order,
+// rev(q) = rev(a) / rev(b) mod x^(n-d+1), where the reciprocal of rev(b) is
+// computed by Newton iteration. Division thus costs a few multiplications.
+func (a PolyOf[E]) FastDivMod(b PolyOf[E]) (PolyOf[E], PolyOf[E], error) {
+	f := a.F
+	a, b = a.trim(), b.trim()
+	d := len(b.Coeff) - 1
+	if d < 0 {
+		return PolyOf[E]{}, PolyOf[E]{}, errDivideByZero
+	}
+	n := len(a.Coeff) - 1
+	if n < d {
+		if _, err := f.Inv(b.Coeff[d]); err != nil {
+			return PolyOf[E]{}, PolyOf[E]{}, err
+		}
+		return PolyOf[E]{F: f}, a, nil
+	}
+
+	m := n - d + 1 // number of quotient coefficients
+	inv, err := reciprocal(f, reversed(b.Coeff), m)
+	if err != nil {
+		return PolyOf[E]{}, PolyOf[E]{}, err
+	}
+	revQ := truncated[E](f, mulCoeff(f, truncated[E](f, reversed(a.Coeff), m), inv), m)
+	q := reversed(revQ)
+
+	// deg r < d, so r = a - b*q modulo x^d
+	bq := truncated[E](f, mulCoeff(f, truncated[E](f, b.Coeff, d), truncated[E](f, q, d)), d)
+	r := make([]E, d)
+	for i := range r {
+		r[i] = f.Sub(a.Coeff[i], bq[i])
+	}
+	return NewPolyOf(f, q...), NewPolyOf(f, r...), nil
+}
+
+// reciprocal returns the first n coefficients of the power series 1/c, which
+// needs c[0] != 0. Each Newton step g <- g (2 - c g) doubles the number of
+// correct coefficients; as c g = 1 + x^k h modulo x^2k, the step only has to
+// compute the upper half -g h.
+func reciprocal[E any](f field.Field[E], c []E, n int) ([]E, error) {
+	g0, err := f.Inv(c[0])
+	if err != nil {
+		return nil, err
+	}
+	g := make([]E, 1, n)
+	g[0] = g0
+	for k := 1; k < n; k *= 2 {
+		next := min(2*k, n)
+		cg := truncated[E](f, mulCoeff(f, truncated[E](f, c, next), g), next)
+		gh := truncated[E](f, mulCoeff(f, g[:next-k], cg[k:next]), next-k)
+		for _, v := range gh {
+			g = append(g, f.Neg(v))
+		}
+	}
+	return g, nil
+}
+
+// reversed returns the coefficients c in reverse order.
+func reversed[E any](c []E) []E {
+	out := make([]E, len(c))
+	for i, v := range c {
+		out[len(c)-1-i] = v
+	}
+	return out
+}
+
+// truncated returns exactly n coefficients of c, that is c modulo x^n padded
+// with zeros.
+func truncated[E any](f ring[E], c []E, n int) []E {
+	if len(c) >= n {
+		return c[:n]
+	}
+	out := make([]E, n)
+	copy(out, c)
+	for i := len(c); i < n; i++ {
+		out[i] = f.Zero()
+	}
+	return out
+}
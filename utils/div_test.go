@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"oec/field"
+)
+
+func TestFastDivMod(t *testing.T) {
+	rnd := rand.New(rand.NewSource(8))
+	goldilocks, _ := field.NewPrime64(0xffffffff00000001)
+	mersenne, _ := field.NewPrime64(1<<61 - 1)
+	for _, f := range []*field.Prime64{goldilocks, mersenne} {
+		for _, size := range [][2]int{{1, 1}, {3, 5}, {10, 1}, {10, 10}, {100, 37}, {700, 300}, {600, 500}} {
+			a := NewPolyOf[uint64](f, randomCoeff[uint64](f, rnd, size[0])...)
+			b := NewPolyOf[uint64](f, randomCoeff[uint64](f, rnd, size[1])...)
+
+			wantQ, wantR, err := a.LongDivMod(b)
+			assert.Nil(t, err, "LongDivMod")
+			assert.True(t, wantQ.Mul(b).Add(wantR).Equal(a), "%v: a = q*b + r", size)
+			assert.True(t, wantR.Degree() < b.Degree(), "%v: deg r < deg b", size)
+
+			q, r, err := a.FastDivMod(b)
+			assert.Nil(t, err, "FastDivMod")
+			assert.True(t, q.Equal(wantQ), "%v: FastDivMod quotient", size)
+			assert.True(t, r.Equal(wantR), "%v: FastDivMod remainder", size)
+
+			q, r, err = a.DivMod(b)
+			assert.Nil(t, err, "DivMod")
+			assert.True(t, q.Equal(wantQ) && r.Equal(wantR), "%v: DivMod", size)
+		}
+	}
+
+	_, _, err := NewPolyOf[uint64](goldilocks, 1, 2).FastDivMod(PolyOf[uint64]{F: goldilocks})
+	assert.NotNil(t, err, "divide by zero")
+}
+
+func BenchmarkDivMod(b *testing.B) {
+	rnd := rand.New(rand.NewSource(9))
+	goldilocks, _ := field.NewPrime64(0xffffffff00000001)
+	mersenne, _ := field.NewPrime64(1<<61 - 1)
+	for _, f := range []*field.Prime64{goldilocks, mersenne} {
+		name := "NTT"
+		if f == mersenne {
+			name = "Karatsuba"
+		}
+		for _, n := range []int{256, 1024, 4096} {
+			x := NewPolyOf[uint64](f, randomCoeff[uint64](f, rnd, 2*n)...)
+			y := NewPolyOf[uint64](f, randomCoeff[uint64](f, rnd, n)...)
+			b.Run(name+"/Long/"+strconv.Itoa(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					x.LongDivMod(y)
+				}
+			})
+			b.Run(name+"/Newton/"+strconv.Itoa(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					x.FastDivMod(y)
+				}
+			})
+		}
+	}
+}
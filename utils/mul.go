@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"math/big"
+
+	"oec/field"
+)
+
+// The thresholds come from BenchmarkMul. Karatsuba beats the schoolbook
+// method from 32 coefficients for Prime elements and breaks even there for
+// Prime64. With the root of unity cached, the transform breaks even with
+// Karatsuba at products of 127 coefficients, loses again just past 128, where
+// padding doubles the transform length, and beats it from about 190.
+const (
+	// karatsubaThreshold is the length of the shorter operand from which
+	// products use Karatsuba multiplication instead of the schoolbook method.
+	karatsubaThreshold = 32
+	// nttThreshold is the length of the product from which products over
+	// fields with a suitable root of unity use the number-theoretic transform.
+	nttThreshold = 192
+)
+
+// ring is the arithmetic polynomial multiplication needs. Every field.Field
+// is a ring.
+type ring[E any] interface {
+	Zero() E
+	Add(a, b E) E
+	Sub(a, b E) E
+	Mul(a, b E) E
+}
+
+// integers is the ring of integers, in which the coefficients of Poly live.
+type integers struct{}
+
+func (integers) Zero() *big.Int             { return big.NewInt(0) }
+func (integers) Add(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) }
+func (integers) Sub(a, b *big.Int) *big.Int { return new(big.Int).Sub(a, b) }
+func (integers) Mul(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) }
+
+// mulCoeff returns the coefficients of the product of the polynomials with
+// coefficients a and b over f, choosing the algorithm by size.
+func mulCoeff[E any](f field.Field[E], a, b []E) []E {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	if min(len(a), len(b)) < karatsubaThreshold {
+		return schoolbookMul[E](f, a, b)
+	}
+	if n := len(a) + len(b) - 1; n >= nttThreshold {
+		if w, err := rootOfUnity(f, NTTSize(n)); err == nil {
+			return nttMul(f, a, b, w)
+		}
+	}
+	return karatsubaMul[E](f, a, b)
+}
+
+// schoolbookMul multiplies every coefficient of a with every one of b.
+func schoolbookMul[E any](r ring[E], a, b []E) []E {
+	out := make([]E, len(a)+len(b)-1)
+	for i := range out {
+		out[i] = r.Zero()
+	}
+	for i, ai := range a {
+		for j, bj := range b {
+			out[i+j] = r.Add(out[i+j], r.Mul(ai, bj))
+		}
+	}
+	return out
+}
+
+// karatsubaMul splits both operands in halves a = a0 + x^m a1 and
+// b = b0 + x^m b1 and gets by with three half-size products, since
+// a0 b1 + a1 b0 = (a0 + a1)(b0 + b1) - a0 b0 - a1 b1.
+func karatsubaMul[E any](r ring[E], a, b []E) []E {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(b) < karatsubaThreshold {
+		return schoolbookMul(r, a, b)
+	}
+	out := make([]E, len(a)+len(b)-1)
+	for i := range out {
+		out[i] = r.Zero()
+	}
+	addAt := func(c []E, shift int) {
+		for i, ci := range c {
+			out[i+shift] = r.Add(out[i+shift], ci)
+		}
+	}
+
+	m := len(a) / 2
+	if len(b) <= m {
+		// unbalanced operands: only split the longer one
+		addAt(karatsubaMul(r, a[:m], b), 0)
+		addAt(karatsubaMul(r, a[m:], b), m)
+		return out
+	}
+
+	a0, a1 := a[:m], a[m:]
+	b0, b1 := b[:m], b[m:]
+	z0 := karatsubaMul(r, a0, b0)
+	z2 := karatsubaMul(r, a1, b1)
+	z1 := karatsubaMul(r, addCoeff(r, a0, a1), addCoeff(r, b0, b1))
+	for i := range z1 {
+		if i < len(z0) {
+			z1[i] = r.Sub(z1[i], z0[i])
+		}
+		if i < len(z2) {
+			z1[i] = r.Sub(z1[i], z2[i])
+		}
+	}
+	addAt(z0, 0)
+	addAt(z1, m)
+	addAt(z2, 2*m)
+	return out
+}
+
+// addCoeff returns the coefficient-wise sum of a and b.
+func addCoeff[E any](r ring[E], a, b []E) []E {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	out := append([]E{}, a...)
+	for i, bi := range b {
+		out[i] = r.Add(out[i], bi)
+	}
+	return out
+}
+
+// nttMul multiplies by transforming both operands, multiplying pointwise and
+// transforming back. w must be a primitive root of unity whose order is the
+// smallest power of two >= len(a) + len(b) - 1.
+func nttMul[E any](f field.Field[E], a, b []E, w E) []E {
+	n := len(a) + len(b) - 1
+	size := NTTSize(n)
+	pad := func(c []E) []E {
+		out := make([]E, size)
+		copy(out, c)
+		for i := len(c); i < size; i++ {
+			out[i] = f.Zero()
+		}
+		return out
+	}
+	fa, err := NTT(f, pad(a), w)
+	if err != nil {
+		return karatsubaMul[E](f, a, b)
+	}
+	fb, _ := NTT(f, pad(b), w)
+	for i := range fa {
+		fa[i] = f.Mul(fa[i], fb[i])
+	}
+	out, err := InverseNTT(f, fa, w)
+	if err != nil {
+		return karatsubaMul[E](f, a, b)
+	}
+	return out[:n]
+}
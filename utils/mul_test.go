@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"oec/field"
+)
+
+func randomCoeff[E any](f field.Field[E], rnd *rand.Rand, n int) []E {
+	coeff := make([]E, n)
+	for i := range coeff {
+		coeff[i] = f.FromInt64(rnd.Int63())
+	}
+	return coeff
+}
+
+func TestMul(t *testing.T) {
+	f, _ := field.NewPrime64(0xffffffff00000001)
+	rnd := rand.New(rand.NewSource(5))
+	for _, size := range [][2]int{{1, 1}, {5, 40}, {31, 32}, {64, 64}, {100, 37}, {300, 290}, {1000, 70}} {
+		a := randomCoeff[uint64](f, rnd, size[0])
+		b := randomCoeff[uint64](f, rnd, size[1])
+		want := schoolbookMul[uint64](f, a, b)
+
+		assert.Equal(t, want, karatsubaMul[uint64](f, a, b), "%v: Karatsuba", size)
+		w, err := RootOfUnity[uint64](f, NTTSize(len(want)))
+		assert.Nil(t, err, "RootOfUnity")
+		assert.Equal(t, want, nttMul[uint64](f, a, b, w), "%v: NTT", size)
+		assert.True(t, NewPolyOf[uint64](f, a...).Mul(NewPolyOf[uint64](f, b...)).Equal(NewPolyOf[uint64](f, want...)), "%v: Mul", size)
+	}
+
+	// no power-of-two roots of unity in characteristic 2
+	g, _ := field.NewGF2m(8, 0)
+	a := randomCoeff[uint16](g, rnd, 200)
+	b := randomCoeff[uint16](g, rnd, 150)
+	got := NewPolyOf[uint16](g, a...).Mul(NewPolyOf[uint16](g, b...))
+	assert.True(t, got.Equal(NewPolyOf[uint16](g, schoolbookMul[uint16](g, a, b)...)), "GF(2^8): Mul")
+}
+
+func TestPoly_MulKaratsuba(t *testing.T) {
+	rnd := rand.New(rand.NewSource(6))
+	op1 := Poly{Coeff: make([]*big.Int, 70)}
+	op2 := Poly{Coeff: make([]*big.Int, 45)}
+	for _, op := range []Poly{op1, op2} {
+		for i := range op.Coeff {
+			op.Coeff[i] = big.NewInt(rnd.Int63n(2001) - 1000)
+		}
+	}
+	want := schoolbookMul[*big.Int](integers{}, op1.Coeff, op2.Coeff)
+
+	var result Poly
+	assert.Nil(t, result.Mul(op1, op2), "Mul")
+	assert.Equal(t, len(want), len(result.Coeff), "length")
+	for i := range want {
+		assert.Equal(t, 0, want[i].Cmp(result.Coeff[i]), "coefficient %d", i)
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	f64, _ := field.NewPrime64(0xffffffff00000001)
+	benchmarkMul[uint64](b, "Prime64", f64)
+	benchmarkMul[*big.Int](b, "Prime", field.NewPrime(new(big.Int).SetUint64(0xffffffff00000001)))
+}
+
+func benchmarkMul[E any](b *testing.B, name string, f field.Field[E]) {
+	rnd := rand.New(rand.NewSource(7))
+	for _, n := range []int{16, 32, 48, 64, 96, 128, 2048} {
+		x := randomCoeff(f, rnd, n)
+		y := randomCoeff(f, rnd, n)
+		size := name + "/" + strconv.Itoa(n)
+		b.Run("Schoolbook/"+size, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				schoolbookMul[E](f, x, y)
+			}
+		})
+		b.Run("Karatsuba/"+size, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				karatsubaMul[E](f, x, y)
+			}
+		})
+		// Mul looks up the cached root of unity on every call
+		b.Run("NTT/"+size, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				w, _ := rootOfUnity(f, NTTSize(2*n-1))
+				nttMul(f, x, y, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"oec/field"
+)
+
+// RootOfUnity returns a primitive n-th root of unity in f, an element w with
+// w^n = 1 and w^j != 1 for 0 < j < n. It exists if and only if n divides q-1.
+func RootOfUnity[E any](f field.Field[E], n int) (E, error) {
+	if n <= 0 {
+		return f.Zero(), errors.New("requires n > 0")
+	}
+	order := new(big.Int).Sub(f.Order(), big.NewInt(1))
+	cofactor, rem := new(big.Int).DivMod(order, big.NewInt(int64(n)), new(big.Int))
+	if rem.Sign() != 0 {
+		return f.Zero(), errors.New("n does not divide the order of the multiplicative group")
+	}
+
+	// w = c^((q-1)/n) has order n unless w^(n/l) = 1 for a prime l | n
+	var primes []int
+	for m, l := n, 2; m > 1; l++ {
+		if l*l > m {
+			l = m
+		}
+		if m%l == 0 {
+			primes = append(primes, l)
+			for m%l == 0 {
+				m /= l
+			}
+		}
+	}
+	for c := int64(1); big.NewInt(c).Cmp(f.Order()) < 0; c++ {
+		w := field.Exp(f, f.FromInt64(c), cofactor)
+		if f.IsZero(w) {
+			continue
+		}
+		primitive := true
+		for _, l := range primes {
+			if f.Equal(field.ExpInt(f, w, n/l), f.One()) {
+				primitive = false
+				break
+			}
+		}
+		if primitive {
+			return w, nil
+		}
+	}
+	return f.Zero(), errors.New("no primitive root of unity found")
+}
+
+// roots caches the roots of unity found by rootOfUnity.
+var roots sync.Map
+
+// rootKey identifies a field of package field and the order of a root of
+// unity in it. Fields of the same order are the same field, except that the
+// elements of GF(2^m) depend on the reduction polynomial.
+type rootKey struct {
+	kind  string
+	order string
+	poly  uint32
+	size  int
+}
+
+// rootOfUnity is RootOfUnity with the roots cached per field and order, so
+// that repeated products do not factor q-1 again. Fields outside package
+// field are not cached.
+func rootOfUnity[E any](f field.Field[E], n int) (E, error) {
+	key := rootKey{order: f.Order().String(), size: n}
+	switch g := any(f).(type) {
+	case *field.Prime:
+		key.kind = "Prime"
+	case *field.Prime64:
+		key.kind = "Prime64"
+	case *field.GF2m:
+		key.kind, key.poly = "GF2m", g.Polynomial()
+	default:
+		return RootOfUnity(f, n)
+	}
+	if w, ok := roots.Load(key); ok {
+		return w.(E), nil
+	}
+	w, err := RootOfUnity(f, n)
+	if err != nil {
+		return w, err
+	}
+	roots.Store(key, w)
+	return w, nil
+}
+
+// NTTSize returns the smallest power of two >= n, the length of the
+// transform that holds n values.
+func NTTSize(n int) int {
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+// NTT returns the number-theoretic transform of a, the evaluations of the
+// polynomial with coefficients a at w^0, w^1, ..., w^(n-1). n = len(a) must be
+// a power of two and w a primitive n-th root of unity.
+func NTT[E any](f field.Field[E], a []E, w E) ([]E, error) {
+	n := len(a)
+	if n == 0 || n&(n-1) != 0 {
+		return nil, errors.New("transform length must be a power of two")
+	}
+	out := make([]E, n)
+	// bit-reversal permutation
+	shift := bits.Len(uint(n)) - 1
+	for i := range a {
+		out[bits.Reverse(uint(i))>>(bits.UintSize-shift)] = a[i]
+	}
+	if n == 1 {
+		return out, nil
+	}
+
+	// roots[s] is a primitive 2^(s+1)-th root of unity
+	roots := make([]E, shift)
+	roots[shift-1] = w
+	for s := shift - 2; s >= 0; s-- {
+		roots[s] = f.Mul(roots[s+1], roots[s+1])
+	}
+	twiddle := make([]E, n/2)
+	for s, length := 0, 2; length <= n; s, length = s+1, length<<1 {
+		half := length / 2
+		twiddle[0] = f.One()
+		for j := 1; j < half; j++ {
+			twiddle[j] = f.Mul(twiddle[j-1], roots[s])
+		}
+		for start := 0; start < n; start += length {
+			for j := 0; j < half; j++ {
+				u := out[start+j]
+				v := f.Mul(out[start+j+half], twiddle[j])
+				out[start+j] = f.Add(u, v)
+				out[start+j+half] = f.Sub(u, v)
+			}
+		}
+	}
+	return out, nil
+}
+
+// InverseNTT returns the coefficients of the polynomial of degree < len(a)
+// whose evaluations at w^0, w^1, ..., w^(n-1) are a.
+func InverseNTT[E any](f field.Field[E], a []E, w E) ([]E, error) {
+	wInv, err := f.Inv(w)
+	if err != nil {
+		return nil, err
+	}
+	out, err := NTT(f, a, wInv)
+	if err != nil {
+		return nil, err
+	}
+	nInv, err := f.Inv(field.MulInt(f, f.One(), len(a)))
+	if err != nil {
+		return nil, errors.New("transform length is a multiple of the characteristic")
+	}
+	for i := range out {
+		out[i] = f.Mul(out[i], nInv)
+	}
+	return out, nil
+}
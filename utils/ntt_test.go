@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"oec/field"
+)
+
+func TestRootOfUnity(t *testing.T) {
+	f := field.NewPrime(big.NewInt(97))
+	for _, n := range []int{1, 2, 3, 8, 12, 96} {
+		w, err := RootOfUnity[*big.Int](f, n)
+		assert.Nil(t, err, "RootOfUnity")
+		x := f.One()
+		for j := 1; j <= n; j++ {
+			x = f.Mul(x, w)
+			assert.Equal(t, j == n, f.Equal(x, f.One()), "order of w")
+		}
+	}
+	_, err := RootOfUnity[*big.Int](f, 5)
+	assert.NotNil(t, err, "5 does not divide 96")
+
+	g, _ := field.NewGF2m(8, 0)
+	w, err := RootOfUnity[uint16](g, 15)
+	assert.Nil(t, err, "RootOfUnity")
+	assert.Equal(t, uint16(1), field.ExpInt[uint16](g, w, 15))
+	assert.NotEqual(t, uint16(1), field.ExpInt[uint16](g, w, 5))
+}
+
+func TestNTT(t *testing.T) {
+	f, _ := field.NewPrime64(0xffffffff00000001)
+	rnd := rand.New(rand.NewSource(2))
+	for _, n := range []int{1, 2, 8, 64} {
+		w, err := RootOfUnity[uint64](f, n)
+		assert.Nil(t, err, "RootOfUnity")
+		a := make([]uint64, n)
+		for i := range a {
+			a[i] = f.FromUint64(rnd.Uint64())
+		}
+
+		got, err := NTT[uint64](f, a, w)
+		assert.Nil(t, err, "NTT")
+		poly := NewPolyOf[uint64](f, a...)
+		x := f.One()
+		for i := range got {
+			assert.Equal(t, poly.Eval(x), got[i], "evaluation at w^i")
+			x = f.Mul(x, w)
+		}
+
+		back, err := InverseNTT[uint64](f, got, w)
+		assert.Nil(t, err, "InverseNTT")
+		assert.Equal(t, a, back, "inverse transform")
+	}
+
+	_, err := NTT[uint64](f, make([]uint64, 6), f.One())
+	assert.NotNil(t, err, "length is not a power of two")
+}
+
+func BenchmarkNTT(b *testing.B) {
+	f, _ := field.NewPrime64(0xffffffff00000001)
+	w, _ := RootOfUnity[uint64](f, 4096)
+	a := make([]uint64, 4096)
+	for i := range a {
+		a[i] = f.FromInt64(int64(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NTT[uint64](f, a, w)
+	}
+}
+
+func TestNTTSize(t *testing.T) {
+	for n, want := range map[int]int{0: 1, 1: 1, 2: 2, 3: 4, 255: 256, 256: 256, 257: 512} {
+		assert.Equal(t, want, NTTSize(n), "NTTSize(%d)", n)
+	}
+}
+
+func TestRootOfUnityCache(t *testing.T) {
+	// GF(2^4) built from two reduction polynomials has the same order but
+	// different cube roots of unity
+	for _, poly := range []uint32{0x13, 0x19, 0x13} {
+		g, _ := field.NewGF2m(4, poly)
+		w, err := rootOfUnity[uint16](g, 3)
+		assert.Nil(t, err, "rootOfUnity")
+		assert.Equal(t, uint16(1), field.ExpInt[uint16](g, w, 3), "%#x: w^3", poly)
+		assert.NotEqual(t, uint16(1), w, "%#x: w", poly)
+	}
+	f := field.NewPrime(big.NewInt(97))
+	w, _ := rootOfUnity[*big.Int](f, 8)
+	v, _ := rootOfUnity[*big.Int](field.NewPrime(big.NewInt(97)), 8)
+	assert.Equal(t, w, v, "cached root")
+	_, err := rootOfUnity[*big.Int](f, 5)
+	assert.NotNil(t, err, "5 does not divide 96")
+}
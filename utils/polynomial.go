@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+
+	"oec/field"
 )
 
 type Poly struct {
@@ -14,7 +16,7 @@ type Poly struct {
 // NewPoly returns a polynomial P(x) = 0 with capacity degree + 1
 func NewPoly(degree int) (Poly, error) {
 	if degree < 0 {
-		return Poly{}, fmt.Errorf(fmt.Sprintf("degree must be non-negative, got %d", degree))
+		return Poly{}, fmt.Errorf("degree must be non-negative, got %d", degree)
 	}
 
 	coeff := make([]*big.Int, degree+1)
@@ -309,6 +311,12 @@ func (poly *Poly) Mul(op1 Poly, op2 Poly) error {
 	deg1 := op1.GetDegree()
 	deg2 := op2.GetDegree()
 
+	if min(deg1, deg2)+1 >= karatsubaThreshold {
+		poly.Coeff = karatsubaMul[*big.Int](integers{}, op1.Coeff[:deg1+1], op2.Coeff[:deg2+1])
+		poly.Coeff = poly.Coeff[:poly.GetDegree()+1]
+		return nil
+	}
+
 	poly.resetToDegree(deg1 + deg2)
 
 	for i := 0; i <= deg1; i++ {
@@ -338,50 +346,50 @@ func (poly Poly) EvalMod(x *big.Int, p *big.Int) *big.Int {
 	return result
 }
 
-// DivMod sets computes q, r such that a = b*q + r.
-// This is an implementation of Euclidean division. The complexity is O(n^3)!!
+// DivMod sets computes q, r such that a = b*q + r over GF(p).
 func DivMod(a Poly, b Poly, p *big.Int) (Poly, Poly, error) {
 	if b.IsZero() {
 		return Poly{}, Poly{}, errors.New("divide by zero")
 	}
+	f := field.NewPrime(p)
+	q, r, err := toPolyOf(a, f).DivMod(toPolyOf(b, f))
+	if err != nil {
+		return Poly{}, Poly{}, err
+	}
+	return FromVecBig(q.Coeff), FromVecBig(r.Coeff), nil
+}
 
-	var q, r Poly
-
-	q.resetToDegree(0)
-	r.DeepCopy(a)
-
-	d := b.GetDegree()
-	c := b.GetLeadingCoefficient()
-
-	// cInv = 1/c
-	cInv := big.NewInt(0)
-	cInv.ModInverse(c, p)
-
-	for r.GetDegree() >= d {
-		lc := r.GetLeadingCoefficient()
-		s, err := NewPoly(r.GetDegree() - d)
-		if err != nil {
-			return Poly{}, Poly{}, err
-		}
-
-		err = s.SetCoefficientBig(r.GetDegree()-d, lc.Mul(lc, cInv))
-		if err != nil {
-			return Poly{}, Poly{}, err
-		}
-		q.AddSelf(s)
-
-		sb := NewEmpty()
-		sb.Mul(s, b)
-
-		// deg r reduces by each iteration
-		r.SubSelf(sb)
+// ExtendedGCD runs the extended Euclidean algorithm on a and b over GF(p).
+// It returns r, s, t with s*a + t*b = r, where r is the first remainder whose
+// degree is below stopDeg; the zero polynomial counts as such a remainder.
+// With stopDeg <= 0 the algorithm runs to completion and r is a (not
+// necessarily monic) greatest common divisor of a and b.
+func ExtendedGCD(a, b Poly, p *big.Int, stopDeg int) (Poly, Poly, Poly, error) {
+	f := field.NewPrime(p)
+	r, s, t, err := toPolyOf(a, f).ExtendedGCD(toPolyOf(b, f), stopDeg)
+	if err != nil {
+		return Poly{}, Poly{}, Poly{}, err
+	}
+	return FromVecBig(r.Coeff), FromVecBig(s.Coeff), FromVecBig(t.Coeff), nil
+}
 
-		// modulo p
-		q.Mod(p)
-		r.Mod(p)
+// GCD returns the monic greatest common divisor of a and b over GF(p).
+func GCD(a, b Poly, p *big.Int) (Poly, error) {
+	f := field.NewPrime(p)
+	g, err := toPolyOf(a, f).GCD(toPolyOf(b, f))
+	if err != nil {
+		return Poly{}, err
 	}
+	return FromVecBig(g.Coeff), nil
+}
 
-	return q, r, nil
+// toPolyOf converts poly to a polynomial over f.
+func toPolyOf(poly Poly, f *field.Prime) PolyOf[*big.Int] {
+	coeff := make([]*big.Int, len(poly.Coeff))
+	for i, c := range poly.Coeff {
+		coeff[i] = f.FromBig(c)
+	}
+	return NewPolyOf[*big.Int](f, coeff...)
 }
 
 func FromVecBig(coeff []*big.Int) Poly {
@@ -7,20 +7,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestNew(t *testing.T) {
+func TestNewPoly(t *testing.T) {
 	ZERO := big.NewInt(0)
 
 	degree := 100
-	poly, err := New(degree)
+	poly, err := NewPoly(degree)
 
-	assert.Nil(t, err, "error in New")
-	assert.Equal(t, degree+1, len(poly.coeff), "coeff len")
+	assert.Nil(t, err, "error in NewPoly")
+	assert.Equal(t, degree+1, len(poly.Coeff), "coeff len")
 
-	for i := 0; i < len(poly.coeff); i++ {
-		assert.Zero(t, poly.coeff[i].Cmp(ZERO))
+	for i := 0; i < len(poly.Coeff); i++ {
+		assert.Zero(t, poly.Coeff[i].Cmp(ZERO))
 	}
 
-	_, err = New(-1)
+	_, err = NewPoly(-1)
 	assert.NotNil(t, err, "negative degree")
 }
 
@@ -30,29 +30,29 @@ func TestNewOne(t *testing.T) {
 	onePoly := NewOne()
 
 	assert.Equal(t, 0, onePoly.GetDegree(), "degree")
-	assert.Equal(t, 1, len(onePoly.coeff), "coeff len")
+	assert.Equal(t, 1, len(onePoly.Coeff), "coeff len")
 
-	assert.Equal(t, 0, ONE.Cmp(onePoly.coeff[0]))
+	assert.Equal(t, 0, ONE.Cmp(onePoly.Coeff[0]))
 }
 
 func TestNewEmpty(t *testing.T) {
 	emptyPoly := NewEmpty()
 
 	assert.Equal(t, 0, emptyPoly.GetDegree(), "degree")
-	assert.Equal(t, int64(0), emptyPoly.coeff[0].Int64(), "const")
+	assert.Equal(t, int64(0), emptyPoly.Coeff[0].Int64(), "const")
 }
 
-func TestNewRand(t *testing.T) {
+func TestNewRandPoly(t *testing.T) {
 	var degree = 100
 	var n = big.NewInt(1000)
 
-	poly, err := NewRand(degree, n)
-	assert.Nil(t, err, "err in NewRand")
+	poly, err := NewRandPoly(degree, n)
+	assert.Nil(t, err, "err in NewRandPoly")
 
-	assert.Equal(t, degree+1, len(poly.coeff), "coeff len")
+	assert.Equal(t, degree+1, len(poly.Coeff), "coeff len")
 
-	for i := range poly.coeff {
-		assert.Equal(t, -1, poly.coeff[i].Cmp(n), "rand range")
+	for i := range poly.Coeff {
+		assert.Equal(t, -1, poly.Coeff[i].Cmp(n), "rand range")
 	}
 }
 
@@ -68,11 +68,11 @@ func TestPolynomial_Add(t *testing.T) {
 	var degree = 10
 	var n = big.NewInt(1000)
 
-	poly1, err := NewRand(degree, n)
-	assert.Nil(t, err, "err in NewRand")
+	poly1, err := NewRandPoly(degree, n)
+	assert.Nil(t, err, "err in NewRandPoly")
 
-	poly2, err := NewRand(degree, n)
-	assert.Nil(t, err, "err in NewRand")
+	poly2, err := NewRandPoly(degree, n)
+	assert.Nil(t, err, "err in NewRandPoly")
 
 	result := NewEmpty()
 
@@ -81,8 +81,8 @@ func TestPolynomial_Add(t *testing.T) {
 
 	var tmp = big.NewInt(0)
 	for i := 0; i <= degree; i++ {
-		tmp.Add(poly1.coeff[i], poly2.coeff[i])
-		assert.Zero(t, result.coeff[i].Cmp(tmp), "add result")
+		tmp.Add(poly1.Coeff[i], poly2.Coeff[i])
+		assert.Zero(t, result.Coeff[i].Cmp(tmp), "add result")
 		tmp.SetInt64(0)
 	}
 }
@@ -102,7 +102,7 @@ func TestPolynomial_Sub(t *testing.T) {
 		op2 := FromVec(test.op2...)
 		expected := FromVec(test.expected...)
 
-		result, _ := New(op1.GetDegree())
+		result, _ := NewPoly(op1.GetDegree())
 		result.Sub(op1, op2)
 
 		assert.True(t, expected.Equal(result))
@@ -173,3 +173,32 @@ func TestPolynomial_EvalMod(t *testing.T) {
 		assert.Equal(t, test.expected, eval.Int64(), p.ToString())
 	}
 }
+
+func TestExtendedGCD(t *testing.T) {
+	mod := big.NewInt(17)
+
+	// a = (x+1)(x+2)(x+3), b = (x+1)(x+5)
+	a := FromVec(6, 11, 6, 1)
+	b := FromVec(5, 6, 1)
+
+	g, s, tt, err := ExtendedGCD(a, b, mod, 0)
+	assert.Nil(t, err, "ExtendedGCD")
+
+	// s*a + t*b = g
+	var sa, tb, sum Poly
+	sa.Mul(s, a)
+	tb.Mul(tt, b)
+	sum.Add(sa, tb)
+	sum.Mod(mod)
+	g.Mod(mod)
+	assert.True(t, sum.Equal(g), "bezout identity")
+
+	monic, err := GCD(a, b, mod)
+	assert.Nil(t, err, "GCD")
+	assert.True(t, monic.Equal(FromVec(1, 1)), monic.ToString())
+
+	// stop at the first remainder of degree below 2
+	r, _, _, err := ExtendedGCD(a, b, mod, 2)
+	assert.Nil(t, err, "ExtendedGCD")
+	assert.True(t, r.GetDegree() < 2)
+}
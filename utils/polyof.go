@@ -0,0 +1,299 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+
+	"oec/field"
+)
+
+// PolyOf is a polynomial over the field F, PolyOf(x) = Coeff[0] + Coeff[1] x + ...
+// It is the field-generic counterpart of Poly. Operations never modify their
+// operands and return polynomials without leading zero coefficients; the
+// zero polynomial has no coefficients and degree -1.
+type PolyOf[E any] struct {
+	F     field.Field[E]
+	Coeff []E
+}
+
+var errDivideByZero = errors.New("divide by zero")
+
+// NewPolyOf returns the polynomial with the given coefficients, lowest degree first.
+func NewPolyOf[E any](f field.Field[E], coeff ...E) PolyOf[E] {
+	return PolyOf[E]{F: f, Coeff: coeff}.trim()
+}
+
+// MonomialOf returns c x^deg.
+func MonomialOf[E any](f field.Field[E], c E, deg int) PolyOf[E] {
+	coeff := make([]E, deg+1)
+	for i := range coeff {
+		coeff[i] = f.Zero()
+	}
+	coeff[deg] = c
+	return NewPolyOf(f, coeff...)
+}
+
+// FromRoots returns (x - roots[0])(x - roots[1])...
+func FromRoots[E any](f field.Field[E], roots []E) PolyOf[E] {
+	coeff := []E{f.One()}
+	for _, r := range roots {
+		// next = x * coeff - r * coeff
+		next := make([]E, len(coeff)+1)
+		next[0] = f.Zero()
+		copy(next[1:], coeff)
+		for i := range coeff {
+			next[i] = f.Sub(next[i], f.Mul(r, coeff[i]))
+		}
+		coeff = next
+	}
+	return NewPolyOf(f, coeff...)
+}
+
+// trim drops leading zero coefficients.
+func (a PolyOf[E]) trim() PolyOf[E] {
+	n := len(a.Coeff)
+	for n > 0 && a.F.IsZero(a.Coeff[n-1]) {
+		n--
+	}
+	return PolyOf[E]{F: a.F, Coeff: a.Coeff[:n]}
+}
+
+// Degree returns the degree of a, or -1 for the zero polynomial.
+func (a PolyOf[E]) Degree() int {
+	return len(a.trim().Coeff) - 1
+}
+
+// IsZero returns if a == 0.
+func (a PolyOf[E]) IsZero() bool {
+	return a.Degree() < 0
+}
+
+// Coefficient returns the coefficient of x^i, which is zero beyond the degree.
+func (a PolyOf[E]) Coefficient(i int) E {
+	if i < 0 || i >= len(a.Coeff) {
+		return a.F.Zero()
+	}
+	return a.Coeff[i]
+}
+
+// Coefficients returns the first n coefficients, padded with zeros.
+func (a PolyOf[E]) Coefficients(n int) []E {
+	out := make([]E, n)
+	for i := range out {
+		out[i] = a.Coefficient(i)
+	}
+	return out
+}
+
+// LeadingCoefficient returns the coefficient of the highest power of x.
+func (a PolyOf[E]) LeadingCoefficient() E {
+	return a.Coefficient(a.Degree())
+}
+
+// Equal returns if a == b.
+func (a PolyOf[E]) Equal(b PolyOf[E]) bool {
+	n := max(len(a.Coeff), len(b.Coeff))
+	for i := 0; i < n; i++ {
+		if !a.F.Equal(a.Coefficient(i), b.Coefficient(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval returns a(x) using Horner's rule.
+func (a PolyOf[E]) Eval(x E) E {
+	f := a.F
+	result := f.Zero()
+	for i := len(a.Coeff) - 1; i >= 0; i-- {
+		result = f.Add(f.Mul(result, x), a.Coeff[i])
+	}
+	return result
+}
+
+// Add returns a + b.
+func (a PolyOf[E]) Add(b PolyOf[E]) PolyOf[E] {
+	coeff := make([]E, max(len(a.Coeff), len(b.Coeff)))
+	for i := range coeff {
+		coeff[i] = a.F.Add(a.Coefficient(i), b.Coefficient(i))
+	}
+	return NewPolyOf(a.F, coeff...)
+}
+
+// Sub returns a - b.
+func (a PolyOf[E]) Sub(b PolyOf[E]) PolyOf[E] {
+	coeff := make([]E, max(len(a.Coeff), len(b.Coeff)))
+	for i := range coeff {
+		coeff[i] = a.F.Sub(a.Coefficient(i), b.Coefficient(i))
+	}
+	return NewPolyOf(a.F, coeff...)
+}
+
+// Scale returns c * a.
+func (a PolyOf[E]) Scale(c E) PolyOf[E] {
+	coeff := make([]E, len(a.Coeff))
+	for i := range coeff {
+		coeff[i] = a.F.Mul(c, a.Coeff[i])
+	}
+	return NewPolyOf(a.F, coeff...)
+}
+
+// Mul returns a * b. Short operands are multiplied by the schoolbook
+// method, longer ones by Karatsuba, and long products over fields with a
+// power-of-two root of unity of sufficient order by the number-theoretic
+// transform.
+func (a PolyOf[E]) Mul(b PolyOf[E]) PolyOf[E] {
+	a, b = a.trim(), b.trim()
+	if len(a.Coeff) == 0 || len(b.Coeff) == 0 {
+		return PolyOf[E]{F: a.F}
+	}
+	return NewPolyOf(a.F, mulCoeff(a.F, a.Coeff, b.Coeff)...)
+}
+
+// DivMod returns q, r such that a = b*q + r and deg r < deg b. Long
+// divisions use Newton iteration, short ones schoolbook long division.
+func (a PolyOf[E]) DivMod(b PolyOf[E]) (PolyOf[E], PolyOf[E], error) {
+	d := b.Degree()
+	if d >= newtonThreshold && a.Degree()-d >= newtonThreshold {
+		return a.FastDivMod(b)
+	}
+	return a.LongDivMod(b)
+}
+
+// Derivative returns the formal derivative of a.
+func (a PolyOf[E]) Derivative() PolyOf[E] {
+	if len(a.Coeff) <= 1 {
+		return PolyOf[E]{F: a.F}
+	}
+	coeff := make([]E, len(a.Coeff)-1)
+	for i := range coeff {
+		coeff[i] = field.MulInt(a.F, a.Coeff[i+1], i+1)
+	}
+	return NewPolyOf(a.F, coeff...)
+}
+
+// Monic returns a divided by its leading coefficient.
+func (a PolyOf[E]) Monic() (PolyOf[E], error) {
+	if a.IsZero() {
+		return a.trim(), nil
+	}
+	lcInv, err := a.F.Inv(a.LeadingCoefficient())
+	if err != nil {
+		return PolyOf[E]{}, err
+	}
+	return a.Scale(lcInv), nil
+}
+
+// ExtendedGCD runs the extended Euclidean algorithm on a and b. It returns
+// r, s, t with s*a + t*b = r, where r is the first remainder whose degree is
+// below stopDeg; the zero polynomial counts as such a remainder. With
+// stopDeg <= 0 the algorithm runs to completion and r is a (not necessarily
+// monic) greatest common divisor of a and b.
+func (a PolyOf[E]) ExtendedGCD(b PolyOf[E], stopDeg int) (PolyOf[E], PolyOf[E], PolyOf[E], error) {
+	f := a.F
+	r0, r1 := a.trim(), b.trim()
+	s0, s1 := NewPolyOf(f, f.One()), PolyOf[E]{F: f}
+	t0, t1 := PolyOf[E]{F: f}, NewPolyOf(f, f.One())
+
+	for !r1.IsZero() {
+		if r1.Degree() < stopDeg {
+			return r1, s1, t1, nil
+		}
+		q, r, err := r0.DivMod(r1)
+		if err != nil {
+			return PolyOf[E]{}, PolyOf[E]{}, PolyOf[E]{}, err
+		}
+		r0, r1 = r1, r
+		s0, s1 = s1, s0.Sub(q.Mul(s1))
+		t0, t1 = t1, t0.Sub(q.Mul(t1))
+	}
+	if stopDeg > 0 {
+		return r1, s1, t1, nil
+	}
+	return r0, s0, t0, nil
+}
+
+// GCD returns the monic greatest common divisor of a and b.
+func (a PolyOf[E]) GCD(b PolyOf[E]) (PolyOf[E], error) {
+	g, _, _, err := a.ExtendedGCD(b, 0)
+	if err != nil {
+		return PolyOf[E]{}, err
+	}
+	return g.Monic()
+}
+
+// PowMod returns a^e mod m.
+func (a PolyOf[E]) PowMod(e *big.Int, m PolyOf[E]) (PolyOf[E], error) {
+	_, base, err := a.DivMod(m)
+	if err != nil {
+		return PolyOf[E]{}, err
+	}
+	_, result, err := NewPolyOf(a.F, a.F.One()).DivMod(m)
+	if err != nil {
+		return PolyOf[E]{}, err
+	}
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		if _, result, err = result.Mul(result).DivMod(m); err != nil {
+			return PolyOf[E]{}, err
+		}
+		if e.Bit(i) == 1 {
+			if _, result, err = result.Mul(base).DivMod(m); err != nil {
+				return PolyOf[E]{}, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// Interpolate returns the polynomial of degree < len(xs) through the points
+// (xs[i], ys[i]). The xs must be distinct.
+func Interpolate[E any](f field.Field[E], xs, ys []E) (PolyOf[E], error) {
+	if len(xs) != len(ys) {
+		return PolyOf[E]{}, errors.New("the input length is different")
+	}
+	// m(x) = prod (x - x_i) and m'(x_i) = prod_{j != i} (x_i - x_j)
+	m := FromRoots(f, xs)
+	deriv := m.Derivative()
+
+	coeff := make([]E, len(xs))
+	for i := range coeff {
+		coeff[i] = f.Zero()
+	}
+	for i, x := range xs {
+		w, err := f.Inv(deriv.Eval(x))
+		if err != nil {
+			return PolyOf[E]{}, errors.New("interpolation points are not distinct")
+		}
+		c := f.Mul(ys[i], w)
+		// m(x) / (x - x_i) by synthetic division, accumulated into the result
+		carry := f.Zero()
+		for j := len(m.Coeff) - 1; j >= 1; j-- {
+			carry = f.Add(m.Coeff[j], f.Mul(carry, x))
+			coeff[j-1] = f.Add(coeff[j-1], f.Mul(c, carry))
+		}
+	}
+	return NewPolyOf(f, coeff...), nil
+}
+
+// LagrangeCoefficients returns the values at x of the Lagrange basis
+// polynomials of the distinct points xs, prod_{j != i} (x - xs[j])/(xs[i] - xs[j]).
+// The value at x of the polynomial of degree < len(xs) through (xs[i], ys[i])
+// is then sum_i ys[i] * l[i].
+func LagrangeCoefficients[E any](f field.Field[E], xs []E, x E) ([]E, error) {
+	l := make([]E, len(xs))
+	for i, xi := range xs {
+		num, den := f.One(), f.One()
+		for j, xj := range xs {
+			if j != i {
+				num = f.Mul(num, f.Sub(x, xj))
+				den = f.Mul(den, f.Sub(xi, xj))
+			}
+		}
+		denInv, err := f.Inv(den)
+		if err != nil {
+			return nil, errors.New("interpolation points are not distinct")
+		}
+		l[i] = f.Mul(num, denInv)
+	}
+	return l, nil
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"oec/field"
+)
+
+func primePoly(f *field.Prime, coeff ...int64) PolyOf[*big.Int] {
+	c := make([]*big.Int, len(coeff))
+	for i, v := range coeff {
+		c[i] = f.FromInt64(v)
+	}
+	return NewPolyOf[*big.Int](f, c...)
+}
+
+func TestPolyOf_DivMod(t *testing.T) {
+	f := field.NewPrime(big.NewInt(13))
+	a := primePoly(f, 3, 0, 7, 1, 5)
+	b := primePoly(f, 2, 1)
+
+	q, r, err := a.DivMod(b)
+	assert.Nil(t, err, "DivMod")
+	assert.Equal(t, 3, q.Degree())
+	assert.True(t, r.Degree() < b.Degree())
+	assert.True(t, q.Mul(b).Add(r).Equal(a), "a = q*b + r")
+
+	_, _, err = a.DivMod(primePoly(f))
+	assert.NotNil(t, err, "divide by zero")
+	assert.Equal(t, -1, primePoly(f, 0, 0).Degree())
+}
+
+func TestInterpolate(t *testing.T) {
+	f := field.NewPrime(big.NewInt(101))
+	want := primePoly(f, 5, 0, 42, 17)
+	xs := []*big.Int{f.FromInt64(1), f.FromInt64(3), f.FromInt64(8), f.FromInt64(50)}
+	ys := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		ys[i] = want.Eval(x)
+	}
+
+	got, err := Interpolate[*big.Int](f, xs, ys)
+	assert.Nil(t, err, "Interpolate")
+	assert.True(t, got.Equal(want), "interpolated polynomial")
+
+	got, err = Interpolate[*big.Int](f, xs[:1], ys[:1])
+	assert.Nil(t, err, "single point")
+	assert.True(t, got.Equal(primePoly(f, ys[0].Int64())), "constant polynomial")
+
+	_, err = Interpolate[*big.Int](f, []*big.Int{f.One(), f.One()}, ys[:2])
+	assert.NotNil(t, err, "repeated point")
+
+	for _, x := range []*big.Int{f.Zero(), f.FromInt64(3), f.FromInt64(77)} {
+		l, err := LagrangeCoefficients[*big.Int](f, xs, x)
+		assert.Nil(t, err, "LagrangeCoefficients")
+		sum := f.Zero()
+		for i := range l {
+			sum = f.Add(sum, f.Mul(ys[i], l[i]))
+		}
+		assert.Equal(t, want.Eval(x), sum, "value at %v", x)
+	}
+	_, err = LagrangeCoefficients[*big.Int](f, []*big.Int{f.One(), f.One()}, f.Zero())
+	assert.NotNil(t, err, "repeated point")
+}
+
+func TestPolyOf_Roots(t *testing.T) {
+	f := field.NewPrime(big.NewInt(1019))
+	roots := []*big.Int{f.FromInt64(3), f.FromInt64(500), f.FromInt64(1018)}
+	// x^2 + 1 is irreducible since 1019 = 3 mod 4
+	poly := FromRoots[*big.Int](f, roots).Mul(primePoly(f, 1, 0, 1))
+
+	got, err := poly.Roots()
+	assert.Nil(t, err, "Roots")
+	assert.Equal(t, len(roots), len(got))
+	for _, r := range got {
+		assert.True(t, f.IsZero(poly.Eval(r)), "root")
+	}
+}
+
+func TestPolyOf_Prime64(t *testing.T) {
+	f, err := field.NewPrime64(1<<61 - 1)
+	assert.Nil(t, err, "NewPrime64")
+	roots := []uint64{f.FromInt64(2), f.FromInt64(-7), f.FromInt64(1 << 40)}
+	poly := FromRoots[uint64](f, roots)
+
+	got, err := poly.Roots()
+	assert.Nil(t, err, "Roots")
+	assert.Equal(t, len(roots), len(got))
+	for _, r := range got {
+		assert.True(t, f.IsZero(poly.Eval(r)), "root")
+	}
+
+	ys := make([]uint64, len(roots))
+	for i := range ys {
+		ys[i] = f.FromInt64(int64(i * i))
+	}
+	interp, err := Interpolate[uint64](f, roots, ys)
+	assert.Nil(t, err, "Interpolate")
+	for i, x := range roots {
+		assert.Equal(t, ys[i], interp.Eval(x))
+	}
+}
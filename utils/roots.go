@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+
+	"oec/field"
+)
+
+// Roots returns the distinct roots of f in GF(p), in no particular order.
+func Roots(f Poly, p *big.Int) ([]*big.Int, error) {
+	return toPolyOf(f, field.NewPrime(p)).Roots()
+}
+
+// Roots returns the distinct roots of a in its field, in no particular order.
+// It keeps the factor gcd(a, x^q - x), which splits into distinct linear
+// factors, and splits it with the Cantor-Zassenhaus algorithm, or with the
+// trace map in characteristic 2.
+func (a PolyOf[E]) Roots() ([]E, error) {
+	f := a.F
+	g := a.trim()
+	if g.IsZero() {
+		return nil, errors.New("every element is a root of the zero polynomial")
+	}
+	if g.Degree() == 0 {
+		return nil, nil
+	}
+
+	// x^q - x mod g
+	x := NewPolyOf(f, f.Zero(), f.One())
+	xq, err := x.PowMod(f.Order(), g)
+	if err != nil {
+		return nil, err
+	}
+	linear, err := g.GCD(xq.Sub(x))
+	if err != nil {
+		return nil, err
+	}
+	return linear.splitLinear(1)
+}
+
+// splitLinear returns the roots of the monic polynomial a, which must be a
+// product of distinct linear factors. next encodes the next element tried
+// for splitting.
+func (a PolyOf[E]) splitLinear(next int64) ([]E, error) {
+	f := a.F
+	switch a.Degree() {
+	case 0:
+		return nil, nil
+	case 1:
+		// a = x + c
+		return []E{f.Neg(a.Coeff[0])}, nil
+	}
+
+	q := f.Order()
+	even := f.Characteristic().Cmp(big.NewInt(2)) == 0
+	for ; big.NewInt(next).Cmp(q) < 0; next++ {
+		c := f.FromInt64(next)
+		var h PolyOf[E]
+		var err error
+		if even {
+			// Tr(c x) = sum_i (c x)^(2^i) takes the values 0 and 1 on about
+			// half of the roots each
+			h, err = a.trace(c)
+		} else {
+			// (x + c)^((q-1)/2) - 1 shares about half of the roots of a
+			h, err = NewPolyOf(f, c, f.One()).PowMod(new(big.Int).Rsh(q, 1), a)
+			h = h.Sub(NewPolyOf(f, f.One()))
+		}
+		if err != nil {
+			return nil, err
+		}
+		d, err := a.GCD(h)
+		if err != nil {
+			return nil, err
+		}
+		if d.Degree() <= 0 || d.Degree() == a.Degree() {
+			continue
+		}
+
+		rest, _, err := a.DivMod(d)
+		if err != nil {
+			return nil, err
+		}
+		left, err := d.splitLinear(next + 1)
+		if err != nil {
+			return nil, err
+		}
+		right, err := rest.splitLinear(next + 1)
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+	}
+	return nil, errors.New("failed to split polynomial")
+}
+
+// trace returns Tr(c x) mod a for a field of order 2^m.
+func (a PolyOf[E]) trace(c E) (PolyOf[E], error) {
+	_, term, err := NewPolyOf(a.F, a.F.Zero(), c).DivMod(a)
+	if err != nil {
+		return PolyOf[E]{}, err
+	}
+	sum := term
+	for i := 1; i < a.F.Order().BitLen()-1; i++ {
+		if _, term, err = term.Mul(term).DivMod(a); err != nil {
+			return PolyOf[E]{}, err
+		}
+		sum = sum.Add(term)
+	}
+	return sum, nil
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoots(t *testing.T) {
+	var tests = []struct {
+		p     int64
+		coeff []int64
+		roots []int64
+	}{
+		// (x-1)(x-2)(x-3)
+		{17, []int64{-6, 11, -6, 1}, []int64{1, 2, 3}},
+		// (x-5)^2 (x^2+1), x^2+1 is irreducible mod 7
+		{7, []int64{25, -10, 26, -10, 1}, []int64{5}},
+		// x^2 + 1 = (x-2)(x-3) mod 5
+		{5, []int64{1, 0, 1}, []int64{2, 3}},
+		{2, []int64{0, 1, 1}, []int64{0, 1}},
+		{1009, []int64{4}, nil},
+	}
+
+	for _, test := range tests {
+		p := big.NewInt(test.p)
+		roots, err := Roots(FromVec(test.coeff...), p)
+		assert.Nil(t, err, "Roots")
+
+		got := make([]int64, len(roots))
+		for i, r := range roots {
+			got[i] = r.Int64()
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		assert.Equal(t, len(test.roots), len(got))
+		for i := range test.roots {
+			assert.Equal(t, test.roots[i], got[i])
+		}
+	}
+
+	_, err := Roots(NewEmpty(), big.NewInt(7))
+	assert.NotNil(t, err, "zero polynomial")
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"errors"
+
+	"oec/field"
+)
+
+// SubproductTree holds the products of (x - xs[i]) over ever larger groups of
+// consecutive points: the leaves are the linear factors and every node is the
+// product of its two children. It turns evaluation at all points and
+// interpolation through them into O(log n) rounds of polynomial
+// multiplication and division.
+type SubproductTree[E any] struct {
+	f      field.Field[E]
+	xs     []E
+	levels [][]PolyOf[E] // levels[0] are the leaves, the last level is the root
+}
+
+// NewSubproductTree builds the subproduct tree of the points xs.
+func NewSubproductTree[E any](f field.Field[E], xs []E) *SubproductTree[E] {
+	leaves := make([]PolyOf[E], len(xs))
+	for i, x := range xs {
+		leaves[i] = NewPolyOf(f, f.Neg(x), f.One())
+	}
+	levels := [][]PolyOf[E]{leaves}
+	for len(levels[len(levels)-1]) > 1 {
+		below := levels[len(levels)-1]
+		level := make([]PolyOf[E], (len(below)+1)/2)
+		for j := range level {
+			if 2*j+1 < len(below) {
+				level[j] = below[2*j].Mul(below[2*j+1])
+			} else {
+				level[j] = below[2*j]
+			}
+		}
+		levels = append(levels, level)
+	}
+	return &SubproductTree[E]{f: f, xs: append([]E{}, xs...), levels: levels}
+}
+
+// Root returns prod (x - xs[i]), or 1 for no points.
+func (t *SubproductTree[E]) Root() PolyOf[E] {
+	if len(t.xs) == 0 {
+		return NewPolyOf(t.f, t.f.One())
+	}
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Eval returns poly(xs[i]) for every point. Going down the tree, the
+// remainder of poly modulo a node is reduced modulo its children; at the
+// leaves it is the value at the point.
+func (t *SubproductTree[E]) Eval(poly PolyOf[E]) ([]E, error) {
+	if len(t.xs) == 0 {
+		return nil, nil
+	}
+	top := len(t.levels) - 1
+	rems := []PolyOf[E]{poly}
+	if poly.Degree() >= len(t.xs) {
+		_, r, err := poly.DivMod(t.levels[top][0])
+		if err != nil {
+			return nil, err
+		}
+		rems[0] = r
+	}
+	for l := top - 1; l >= 1; l-- {
+		next := make([]PolyOf[E], len(t.levels[l]))
+		for j := range next {
+			parent := rems[j/2]
+			if parent.Degree() < t.levels[l][j].Degree() {
+				next[j] = parent
+				continue
+			}
+			_, r, err := parent.DivMod(t.levels[l][j])
+			if err != nil {
+				return nil, err
+			}
+			next[j] = r
+		}
+		rems = next
+	}
+	values := make([]E, len(t.xs))
+	for i, x := range t.xs {
+		// the remainder modulo x - x_i is the value at x_i
+		values[i] = rems[i/2].Eval(x)
+	}
+	return values, nil
+}
+
+// Interpolate returns the polynomial of degree < len(xs) with value ys[i] at
+// xs[i]. With m the root, it is sum_i ys[i]/m'(xs[i]) * m(x)/(x - xs[i]),
+// and the sum is formed by combining the two halves of every node.
+func (t *SubproductTree[E]) Interpolate(ys []E) (PolyOf[E], error) {
+	f := t.f
+	if len(ys) != len(t.xs) {
+		return PolyOf[E]{}, errors.New("the input length is different")
+	}
+	if len(ys) == 0 {
+		return PolyOf[E]{F: f}, nil
+	}
+	weights, err := t.Eval(t.Root().Derivative())
+	if err != nil {
+		return PolyOf[E]{}, err
+	}
+	sums := make([]PolyOf[E], len(ys))
+	for i := range ys {
+		w, err := f.Inv(weights[i])
+		if err != nil {
+			return PolyOf[E]{}, errors.New("interpolation points are not distinct")
+		}
+		sums[i] = NewPolyOf(f, f.Mul(ys[i], w))
+	}
+	for l := 0; l+1 < len(t.levels); l++ {
+		below := t.levels[l]
+		next := make([]PolyOf[E], len(t.levels[l+1]))
+		for j := range next {
+			if 2*j+1 < len(below) {
+				next[j] = sums[2*j].Mul(below[2*j+1]).Add(sums[2*j+1].Mul(below[2*j]))
+			} else {
+				next[j] = sums[2*j]
+			}
+		}
+		sums = next
+	}
+	return sums[0], nil
+}
+
+// MultiEval returns poly(points[i]) for every point using a subproduct tree.
+func MultiEval[E any](poly PolyOf[E], points []E) ([]E, error) {
+	return NewSubproductTree(poly.F, points).Eval(poly)
+}
+
+// FastInterpolate returns the polynomial of degree < len(xs) through the
+// points (xs[i], ys[i]) using a subproduct tree. The xs must be distinct.
+func FastInterpolate[E any](f field.Field[E], xs, ys []E) (PolyOf[E], error) {
+	if len(xs) != len(ys) {
+		return PolyOf[E]{}, errors.New("the input length is different")
+	}
+	return NewSubproductTree(f, xs).Interpolate(ys)
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"oec/field"
+)
+
+func TestSubproductTree(t *testing.T) {
+	f := field.NewPrime(big.NewInt(1000003))
+	rnd := rand.New(rand.NewSource(4))
+	for _, n := range []int{1, 2, 3, 7, 16, 33} {
+		xs := make([]*big.Int, n)
+		for i, j := range rnd.Perm(1000)[:n] {
+			xs[i] = f.FromInt64(int64(j))
+		}
+		coeff := make([]*big.Int, n+5)
+		for i := range coeff {
+			coeff[i] = f.FromInt64(rnd.Int63())
+		}
+		poly := NewPolyOf[*big.Int](f, coeff...)
+
+		values, err := MultiEval(poly, xs)
+		assert.Nil(t, err, "MultiEval")
+		for i, x := range xs {
+			assert.Equal(t, poly.Eval(x), values[i], "value at x_%d", i)
+		}
+
+		want, _ := Interpolate[*big.Int](f, xs, values)
+		got, err := FastInterpolate[*big.Int](f, xs, values)
+		assert.Nil(t, err, "FastInterpolate")
+		assert.True(t, got.Equal(want), "n=%d: interpolated polynomial", n)
+		assert.True(t, NewSubproductTree[*big.Int](f, xs).Root().Equal(FromRoots[*big.Int](f, xs)), "root")
+	}
+
+	xs := []*big.Int{f.FromInt64(1), f.FromInt64(2), f.FromInt64(1)}
+	_, err := FastInterpolate[*big.Int](f, xs, xs)
+	assert.NotNil(t, err, "repeated point")
+}
+
+func BenchmarkInterpolate(b *testing.B) {
+	f, _ := field.NewPrime64(1<<61 - 1)
+	xs := make([]uint64, 1024)
+	for i := range xs {
+		xs[i] = f.FromInt64(int64(3*i + 1))
+	}
+	b.Run("Lagrange", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Interpolate[uint64](f, xs, xs)
+		}
+	})
+	b.Run("SubproductTree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FastInterpolate[uint64](f, xs, xs)
+		}
+	})
+}
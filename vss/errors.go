@@ -0,0 +1,18 @@
+package vss
+
+import "errors"
+
+// errNotSafePrime is returned if a group is requested for a number that is not a safe prime.
+var errNotSafePrime = errors.New("not a safe prime")
+
+// errCommitmentSize is returned if a commitment does not have t+1 entries.
+var errCommitmentSize = errors.New("commitment does not match the threshold")
+
+// errTooFewValidShares is returned if fewer than t+1 shares pass verification.
+var errTooFewValidShares = errors.New("too few valid shares")
+
+// errNotInGroup is returned if a commitment holds an element outside the subgroup of order q.
+var errNotInGroup = errors.New("commitment is not in the group")
+
+// errDisqualified is returned if the dealer fails to resolve the complaints against it.
+var errDisqualified = errors.New("dealer is disqualified")
@@ -0,0 +1,137 @@
+package vss
+
+import (
+	"math/big"
+
+	"oec/shamir"
+)
+
+// Commitment holds g^a_j for the coefficients a_0, ..., a_t of a sharing
+// polynomial f, so that anybody can compute g^f(x) without learning f.
+type Commitment []*big.Int
+
+// Feldman is the verifiable secret sharing of Feldman: the dealer shares the
+// secret with a random polynomial f of degree <= t over GF(q) and publishes
+// the commitment g^a_j to every coefficient. Party i accepts its share s_i
+// if g^s_i = prod_j C_j^(i^j). The commitment reveals g^secret, which is
+// fine when the secret is random, as for a key, but not for low-entropy
+// secrets.
+type Feldman struct {
+	grp    *Group
+	scheme *shamir.Scheme[*big.Int]
+}
+
+// NewFeldman returns the Feldman VSS with threshold t among n parties in grp.
+func NewFeldman(grp *Group, t, n int) (*Feldman, error) {
+	scheme, err := shamir.NewScheme[*big.Int](t, n, grp.Field())
+	if err != nil {
+		return nil, err
+	}
+	return &Feldman{grp: grp, scheme: scheme}, nil
+}
+
+// Group returns the group of the commitments.
+func (v *Feldman) Group() *Group {
+	return v.grp
+}
+
+// Scheme returns the Shamir scheme of the shares.
+func (v *Feldman) Scheme() *shamir.Scheme[*big.Int] {
+	return v.scheme
+}
+
+// Deal shares secret mod q and returns the commitment to the sharing
+// polynomial together with the shares of all n parties.
+func (v *Feldman) Deal(secret *big.Int) (Commitment, []shamir.Share[*big.Int], error) {
+	f := v.scheme.Field()
+	poly, err := v.scheme.RandomPoly(f.FromBig(secret))
+	if err != nil {
+		return nil, nil, err
+	}
+	shares, err := v.scheme.Shares(poly)
+	if err != nil {
+		return nil, nil, err
+	}
+	coeff := poly.Coefficients(v.scheme.Threshold() + 1)
+	c := make(Commitment, len(coeff))
+	for j, a := range coeff {
+		c[j] = v.grp.Exp(v.grp.G, a)
+	}
+	return c, shares, nil
+}
+
+// CheckCommitment verifies that c has t+1 entries, all in the group.
+func (v *Feldman) CheckCommitment(c Commitment) error {
+	return v.grp.checkCommitment(c, v.scheme.Threshold())
+}
+
+// Verify reports whether share is consistent with the commitment c. Shares
+// with an index outside 1..n or without a value fail.
+func (v *Feldman) Verify(c Commitment, share shamir.Share[*big.Int]) bool {
+	if v.CheckCommitment(c) != nil || share.Scheme() != v.scheme {
+		return false
+	}
+	if share.Index < 1 || share.Index > v.scheme.Parties() || share.Value == nil {
+		return false
+	}
+	want := v.grp.evalCommitment(c, v.scheme.Point(share.Index))
+	return v.grp.Exp(v.grp.G, share.Value).Cmp(want) == 0
+}
+
+// Reconstruct drops the shares that fail verification against c and
+// interpolates the secret from the others, of which there must be at least
+// t+1. It also returns the indices of the dropped shares.
+func (v *Feldman) Reconstruct(c Commitment, shares []shamir.Share[*big.Int]) (*big.Int, []int, error) {
+	return reconstruct(v.grp, v.scheme, c, shares, func(share shamir.Share[*big.Int]) (shamir.Share[*big.Int], bool) {
+		return share, v.Verify(c, share)
+	})
+}
+
+// reconstruct implements Reconstruct of Feldman and Pedersen VSS. verify
+// returns the Shamir share carried by a share and whether the share is
+// consistent with c.
+func reconstruct[S any](grp *Group, scheme *shamir.Scheme[*big.Int], c Commitment, shares []S, verify func(S) (shamir.Share[*big.Int], bool)) (*big.Int, []int, error) {
+	if err := grp.checkCommitment(c, scheme.Threshold()); err != nil {
+		return nil, nil, err
+	}
+	var valid []shamir.Share[*big.Int]
+	var rejected []int
+	for _, s := range shares {
+		if share, ok := verify(s); ok {
+			valid = append(valid, share)
+		} else {
+			rejected = append(rejected, share.Index)
+		}
+	}
+	if len(valid) < scheme.Threshold()+1 {
+		return nil, rejected, errTooFewValidShares
+	}
+	secret, err := scheme.Reconstruct(valid)
+	if err != nil {
+		return nil, rejected, err
+	}
+	return secret, rejected, nil
+}
+
+// checkCommitment verifies that c has t+1 entries, all in the group.
+func (grp *Group) checkCommitment(c Commitment, t int) error {
+	if len(c) != t+1 {
+		return errCommitmentSize
+	}
+	for _, cj := range c {
+		if cj == nil || !grp.Contains(cj) {
+			return errNotInGroup
+		}
+	}
+	return nil
+}
+
+// evalCommitment returns prod_j c_j^(x^j), which is g^f(x) for the
+// commitment c to f, by Horner's rule in the exponent.
+func (grp *Group) evalCommitment(c Commitment, x *big.Int) *big.Int {
+	acc := new(big.Int).Set(c[len(c)-1])
+	for j := len(c) - 2; j >= 0; j-- {
+		acc = grp.Mul(grp.Exp(acc, x), c[j])
+	}
+	return acc
+}
@@ -0,0 +1,74 @@
+package vss
+
+import (
+	"math/big"
+	"testing"
+
+	"oec/shamir"
+)
+
+func TestFeldman(t *testing.T) {
+	grp, err := NewGroup(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt, n := 2, 7
+	v, err := NewFeldman(grp, tt, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := big.NewInt(31337)
+	c, shares, err := v.Deal(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c) != tt+1 || c[0].Cmp(grp.Exp(grp.G, secret)) != 0 {
+		t.Fatalf("commitment %v", c)
+	}
+	for _, share := range shares {
+		if !v.Verify(c, share) {
+			t.Fatalf("share %d fails verification", share.Index)
+		}
+	}
+
+	// a cheating dealer hands party 3 a share off the committed polynomial
+	f := v.Scheme().Field()
+	shares[2].Value = f.Add(shares[2].Value, f.One())
+	if v.Verify(c, shares[2]) {
+		t.Errorf("wrong share verified")
+	}
+	shares[5].Value = f.Zero()
+	got, rejected, err := v.Reconstruct(c, shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Cmp(secret) != 0 {
+		t.Errorf("reconstructed %v", got)
+	}
+	if len(rejected) != 2 || rejected[0] != 3 || rejected[1] != 6 {
+		t.Errorf("rejected %v", rejected)
+	}
+
+	if _, _, err := v.Reconstruct(c, []shamir.Share[*big.Int]{shares[0], shares[2], shares[5]}); err == nil {
+		t.Errorf("reconstructed from one valid share")
+	}
+	if _, _, err := v.Reconstruct(c[:tt], shares); err == nil {
+		t.Errorf("accepted a short commitment")
+	}
+	// shares forged by hand must not reach the commitment evaluation
+	forged := []shamir.Share[*big.Int]{shares[0], shares[0], shares[0]}
+	forged[0].Index, forged[1].Index, forged[2].Value = 0, n+1, nil
+	for _, share := range forged {
+		if v.Verify(c, share) {
+			t.Errorf("verified share %d with value %v", share.Index, share.Value)
+		}
+	}
+	if _, rejected, err := v.Reconstruct(c, append(forged, shares[1], shares[3])); err == nil || len(rejected) != 3 {
+		t.Errorf("reconstructed from forged shares, rejected %v", rejected)
+	}
+	bad := append(Commitment{}, c...)
+	bad[1] = new(big.Int).Sub(grp.P, big.NewInt(1))
+	if v.Verify(bad, shares[0]) {
+		t.Errorf("accepted a commitment outside the group")
+	}
+}
@@ -0,0 +1,131 @@
+// Package vss implements verifiable secret sharing in the subgroup of prime
+// order q of Z_p^* for a safe prime p = 2q+1. The shares live in GF(q) and
+// are Shamir shares of package shamir; the dealer additionally publishes
+// commitments in the group, against which every party checks its share.
+package vss
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"oec/field"
+	"oec/utils"
+)
+
+// Group is the subgroup of order Q of the quadratic residues modulo the safe
+// prime P = 2Q+1, generated by G.
+type Group struct {
+	P *big.Int
+	Q *big.Int
+	G *big.Int
+}
+
+// NewGroup generates a safe prime of the given bit length and a random
+// generator of its subgroup of order q.
+func NewGroup(bits int) (*Group, error) {
+	if bits < 3 {
+		return nil, errors.New("requires at least 3 bits")
+	}
+	values := make(chan *big.Int, 1)
+	p, err := utils.GenerateSafePrime(bits, values, make(chan int))
+	if err != nil {
+		return nil, err
+	}
+	return NewGroupFromSafePrime(p)
+}
+
+// NewGroupFromSafePrime returns the group of the safe prime p with a random
+// generator.
+func NewGroupFromSafePrime(p *big.Int) (*Group, error) {
+	grp, err := newGroup(p)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		h, err := rand.Int(rand.Reader, p)
+		if err != nil {
+			return nil, err
+		}
+		if g := grp.square(h); grp.IsGenerator(g) {
+			grp.G = g
+			return grp, nil
+		}
+	}
+}
+
+// newGroup checks that p is a safe prime and returns its group without a
+// generator.
+func newGroup(p *big.Int) (*Group, error) {
+	if p.Cmp(big.NewInt(5)) < 0 || !p.ProbablyPrime(20) {
+		return nil, errNotSafePrime
+	}
+	q := new(big.Int).Rsh(p, 1)
+	if !q.ProbablyPrime(20) {
+		return nil, errNotSafePrime
+	}
+	return &Group{P: new(big.Int).Set(p), Q: q}, nil
+}
+
+// square maps h onto the subgroup of quadratic residues.
+func (grp *Group) square(h *big.Int) *big.Int {
+	return new(big.Int).Exp(h, big.NewInt(2), grp.P)
+}
+
+// HashToGroup deterministically maps seed and label to a generator of the
+// subgroup: SHA-256 in counter mode gives an integer x with 128 bits more
+// than p, and x^2 mod p is a quadratic residue. Nobody knows the discrete
+// logarithms between generators hashed from different labels.
+func (grp *Group) HashToGroup(seed []byte, label string) *big.Int {
+	size := (grp.P.BitLen() + 128 + 7) / 8
+	for counter := uint32(0); ; counter++ {
+		var buf []byte
+		for block := uint32(0); len(buf) < size; block++ {
+			h := sha256.New()
+			binary.Write(h, binary.BigEndian, uint32(len(label)))
+			h.Write([]byte(label))
+			binary.Write(h, binary.BigEndian, uint32(len(seed)))
+			h.Write(seed)
+			binary.Write(h, binary.BigEndian, counter)
+			binary.Write(h, binary.BigEndian, block)
+			buf = h.Sum(buf)
+		}
+		x := new(big.Int).SetBytes(buf)
+		if g := grp.square(x.Mod(x, grp.P)); grp.IsGenerator(g) {
+			return g
+		}
+	}
+}
+
+// Field returns GF(q), the field of the exponents and of the shares.
+func (grp *Group) Field() *field.Prime {
+	return field.NewPrime(grp.Q)
+}
+
+// Contains reports whether x is an element of the subgroup of order q.
+func (grp *Group) Contains(x *big.Int) bool {
+	if x.Sign() <= 0 || x.Cmp(grp.P) >= 0 {
+		return false
+	}
+	return new(big.Int).Exp(x, grp.Q, grp.P).Cmp(utils.ONE) == 0
+}
+
+// IsGenerator reports whether g generates the subgroup, that is whether it
+// is an element other than 1. The subgroup has prime order, so every other
+// element generates it.
+func (grp *Group) IsGenerator(g *big.Int) bool {
+	return g.Cmp(utils.ONE) != 0 && grp.Contains(g)
+}
+
+// Exp returns base^e mod p.
+func (grp *Group) Exp(base, e *big.Int) *big.Int {
+	return new(big.Int).Exp(base, e, grp.P)
+}
+
+// Mul returns a*b mod p.
+func (grp *Group) Mul(a, b *big.Int) *big.Int {
+	c := new(big.Int).Mul(a, b)
+	return c.Mod(c, grp.P)
+}
@@ -0,0 +1,41 @@
+package vss
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNewGroup(t *testing.T) {
+	grp, err := NewGroup(64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grp.P.BitLen() != 64 {
+		t.Errorf("p has %d bits", grp.P.BitLen())
+	}
+	if new(big.Int).Add(new(big.Int).Lsh(grp.Q, 1), big.NewInt(1)).Cmp(grp.P) != 0 {
+		t.Errorf("p != 2q+1")
+	}
+	if !grp.IsGenerator(grp.G) || grp.Exp(grp.G, grp.Q).Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("g = %v does not generate the subgroup of order q", grp.G)
+	}
+	// -1 is not a square modulo a safe prime
+	if grp.Contains(new(big.Int).Sub(grp.P, big.NewInt(1))) {
+		t.Errorf("p-1 is in the subgroup")
+	}
+}
+
+func TestNewGroupFromSafePrime(t *testing.T) {
+	for _, p := range []int64{15, 29, 31} {
+		if _, err := NewGroupFromSafePrime(big.NewInt(p)); err == nil {
+			t.Errorf("accepted %d", p)
+		}
+	}
+	grp, err := NewGroupFromSafePrime(big.NewInt(2039))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grp.Q.Int64() != 1019 || !grp.IsGenerator(grp.G) {
+		t.Errorf("q = %v, g = %v", grp.Q, grp.G)
+	}
+}
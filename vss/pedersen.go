@@ -0,0 +1,179 @@
+package vss
+
+import (
+	"math/big"
+
+	"oec/shamir"
+)
+
+// PedersenShare is the share of a party in Pedersen VSS: its share of the
+// secret together with its share of the blinding polynomial.
+type PedersenShare struct {
+	shamir.Share[*big.Int]
+	Blinding *big.Int
+}
+
+// Pedersen is the verifiable secret sharing of Pedersen: besides the sharing
+// polynomial f the dealer picks a random blinding polynomial r of degree
+// <= t and commits to the coefficients as C_j = g^a_j h^b_j. Party i accepts
+// (f(i), r(i)) if g^f(i) h^r(i) = prod_j C_j^(i^j). As long as log_g h is
+// unknown, the commitment reveals nothing about the secret, not even to an
+// unbounded adversary, and the dealer cannot open it to a second polynomial.
+type Pedersen struct {
+	grp    *Group
+	g      *big.Int
+	h      *big.Int
+	scheme *shamir.Scheme[*big.Int]
+}
+
+// PedersenDeal is the output of a dealer: the public commitment and the
+// private shares of all n parties, indexed by party index - 1.
+type PedersenDeal struct {
+	Commitment Commitment
+	Shares     []PedersenShare
+}
+
+// NewPedersen returns the Pedersen VSS with threshold t among n parties in
+// grp. The generators g and h are hashed from seed, so every party can derive
+// them and check that nobody chose h with a known logarithm.
+func NewPedersen(grp *Group, seed []byte, t, n int) (*Pedersen, error) {
+	scheme, err := shamir.NewScheme[*big.Int](t, n, grp.Field())
+	if err != nil {
+		return nil, err
+	}
+	return &Pedersen{
+		grp:    grp,
+		g:      grp.HashToGroup(seed, "pedersen g"),
+		h:      grp.HashToGroup(seed, "pedersen h"),
+		scheme: scheme,
+	}, nil
+}
+
+// Generators returns g and h.
+func (v *Pedersen) Generators() (*big.Int, *big.Int) {
+	return v.g, v.h
+}
+
+// Scheme returns the Shamir scheme of the shares.
+func (v *Pedersen) Scheme() *shamir.Scheme[*big.Int] {
+	return v.scheme
+}
+
+// Deal shares secret mod q with a fresh blinding polynomial.
+func (v *Pedersen) Deal(secret *big.Int) (*PedersenDeal, error) {
+	f := v.scheme.Field()
+	poly, err := v.scheme.RandomPoly(f.FromBig(secret))
+	if err != nil {
+		return nil, err
+	}
+	blinding, err := f.Rand(nil)
+	if err != nil {
+		return nil, err
+	}
+	blindPoly, err := v.scheme.RandomPoly(blinding)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := v.scheme.Shares(poly)
+	if err != nil {
+		return nil, err
+	}
+	blindShares, err := v.scheme.Shares(blindPoly)
+	if err != nil {
+		return nil, err
+	}
+
+	t := v.scheme.Threshold()
+	a, b := poly.Coefficients(t+1), blindPoly.Coefficients(t+1)
+	deal := &PedersenDeal{
+		Commitment: make(Commitment, t+1),
+		Shares:     make([]PedersenShare, len(shares)),
+	}
+	for j := range deal.Commitment {
+		deal.Commitment[j] = v.commit(a[j], b[j])
+	}
+	for i, share := range shares {
+		deal.Shares[i] = PedersenShare{Share: share, Blinding: blindShares[i].Value}
+	}
+	return deal, nil
+}
+
+// commit returns g^a h^b.
+func (v *Pedersen) commit(a, b *big.Int) *big.Int {
+	return v.grp.Mul(v.grp.Exp(v.g, a), v.grp.Exp(v.h, b))
+}
+
+// CheckCommitment verifies that c has t+1 entries, all in the group.
+func (v *Pedersen) CheckCommitment(c Commitment) error {
+	return v.grp.checkCommitment(c, v.scheme.Threshold())
+}
+
+// Verify reports whether share is consistent with the commitment c. Shares
+// with an index outside 1..n or without a value or blinding fail. A party
+// whose share fails files a complaint against the dealer.
+func (v *Pedersen) Verify(c Commitment, share PedersenShare) bool {
+	if v.CheckCommitment(c) != nil || share.Scheme() != v.scheme {
+		return false
+	}
+	if share.Index < 1 || share.Index > v.scheme.Parties() || share.Value == nil || share.Blinding == nil {
+		return false
+	}
+	want := v.grp.evalCommitment(c, v.scheme.Point(share.Index))
+	return v.commit(share.Value, share.Blinding).Cmp(want) == 0
+}
+
+// Answer returns the shares of the complaining parties, which the dealer
+// broadcasts in response to their complaints.
+func (d *PedersenDeal) Answer(complaints []int) []PedersenShare {
+	answers := make([]PedersenShare, 0, len(complaints))
+	for _, index := range complaints {
+		if index >= 1 && index <= len(d.Shares) {
+			answers = append(answers, d.Shares[index-1])
+		}
+	}
+	return answers
+}
+
+// ResolveComplaints decides the complaints of the listed parties given the
+// shares the dealer broadcast in answer. The dealer is disqualified if more
+// than t parties complain, since then an honest party must have, or if it
+// leaves a complaint unanswered or answers with a share that fails
+// verification. Repeated complaints of a party count once. Otherwise the
+// complaining parties adopt the broadcast shares, which are returned in the
+// order of their first complaint.
+func (v *Pedersen) ResolveComplaints(c Commitment, complaints []int, answers []PedersenShare) ([]PedersenShare, error) {
+	seen := make(map[int]bool, len(complaints))
+	var distinct []int
+	for _, index := range complaints {
+		if !seen[index] {
+			seen[index] = true
+			distinct = append(distinct, index)
+		}
+	}
+	complaints = distinct
+	if len(complaints) > v.scheme.Threshold() {
+		return nil, errDisqualified
+	}
+	byIndex := make(map[int]PedersenShare, len(answers))
+	for _, answer := range answers {
+		byIndex[answer.Index] = answer
+	}
+	resolved := make([]PedersenShare, len(complaints))
+	for i, index := range complaints {
+		answer, ok := byIndex[index]
+		if !ok || !v.Verify(c, answer) {
+			return nil, errDisqualified
+		}
+		resolved[i] = answer
+	}
+	return resolved, nil
+}
+
+// Reconstruct drops the shares that fail verification against c and
+// interpolates the secret from the others, of which there must be at least
+// t+1. It also returns the indices of the dropped shares.
+func (v *Pedersen) Reconstruct(c Commitment, shares []PedersenShare) (*big.Int, []int, error) {
+	return reconstruct(v.grp, v.scheme, c, shares, func(share PedersenShare) (shamir.Share[*big.Int], bool) {
+		return share.Share, v.Verify(c, share)
+	})
+}
@@ -0,0 +1,107 @@
+package vss
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPedersen(t *testing.T) {
+	grp, err := NewGroup(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt, n := 2, 7
+	v, err := NewPedersen(grp, []byte("session 1"), tt, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, h := v.Generators()
+	if !grp.IsGenerator(g) || !grp.IsGenerator(h) || g.Cmp(h) == 0 {
+		t.Fatalf("g = %v, h = %v", g, h)
+	}
+	same, _ := NewPedersen(grp, []byte("session 1"), tt, n)
+	other, _ := NewPedersen(grp, []byte("session 2"), tt, n)
+	if g2, h2 := same.Generators(); g2.Cmp(g) != 0 || h2.Cmp(h) != 0 {
+		t.Errorf("generators are not deterministic")
+	}
+	if g2, h2 := other.Generators(); g2.Cmp(g) == 0 || h2.Cmp(h) == 0 {
+		t.Errorf("generators do not depend on the seed")
+	}
+
+	secret := big.NewInt(7)
+	deal, err := v.Deal(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deal.Commitment[0].Cmp(grp.Exp(g, secret)) == 0 {
+		t.Errorf("commitment reveals g^secret")
+	}
+	for _, share := range deal.Shares {
+		if !v.Verify(deal.Commitment, share) {
+			t.Fatalf("share %d fails verification", share.Index)
+		}
+	}
+
+	// party 2 receives a wrong share and complains
+	f := v.Scheme().Field()
+	received := append([]PedersenShare{}, deal.Shares...)
+	received[1].Blinding = f.Add(received[1].Blinding, f.One())
+	if v.Verify(deal.Commitment, received[1]) {
+		t.Fatalf("wrong share verified")
+	}
+	complaints := []int{2}
+	resolved, err := v.ResolveComplaints(deal.Commitment, complaints, deal.Answer(complaints))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved[0].Value.Cmp(deal.Shares[1].Value) != 0 {
+		t.Errorf("complaint resolved with another share")
+	}
+	received[1] = resolved[0]
+
+	// a dealer that answers with the wrong share or not at all is disqualified
+	if _, err := v.ResolveComplaints(deal.Commitment, complaints, []PedersenShare{received[0]}); err == nil {
+		t.Errorf("unanswered complaint accepted")
+	}
+	bad := deal.Answer(complaints)
+	bad[0].Value = f.Add(bad[0].Value, f.One())
+	if _, err := v.ResolveComplaints(deal.Commitment, complaints, bad); err == nil {
+		t.Errorf("wrong answer accepted")
+	}
+	if _, err := v.ResolveComplaints(deal.Commitment, []int{1, 2, 3}, deal.Answer([]int{1, 2, 3})); err == nil {
+		t.Errorf("more than t complaints accepted")
+	}
+
+	// a party complaining repeatedly does not push the count past t
+	resolved, err = v.ResolveComplaints(deal.Commitment, []int{2, 2, 2, 4}, deal.Answer([]int{2, 4}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved) != 2 || resolved[0].Index != 2 || resolved[1].Index != 4 {
+		t.Errorf("resolved %v", resolved)
+	}
+
+	// answers forged by hand are rejected without evaluating the commitment
+	forged := deal.Answer([]int{2, 2})
+	forged[0].Index, forged[1].Value = n+1, nil
+	for _, answer := range forged {
+		if v.Verify(deal.Commitment, answer) {
+			t.Errorf("verified share %d with value %v", answer.Index, answer.Value)
+		}
+		if _, err := v.ResolveComplaints(deal.Commitment, []int{answer.Index}, []PedersenShare{answer}); err == nil {
+			t.Errorf("forged answer accepted")
+		}
+	}
+
+	received[4].Value = f.Zero()
+	got, rejected, err := v.Reconstruct(deal.Commitment, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Cmp(secret) != 0 {
+		t.Errorf("reconstructed %v", got)
+	}
+	if len(rejected) != 1 || rejected[0] != 5 {
+		t.Errorf("rejected %v", rejected)
+	}
+}